@@ -1,10 +1,22 @@
+// Package algorithms has implementations of the popular sorting algorithms
+// and some tools built on top of them.
+//
+// Every sort in the package is a no-op on nil, empty and single element
+// slices (and nil lists, maps and readers with nothing in them). The
+// functions that have to return an element can't do that on empty input:
+// Min, Max and MinMax return ErrEmptySlice, QuickSelect, QuickSelectLargest,
+// IntroSelect, NthElement and Percentiles panic since there is no valid k.
 package algorithms
 
 import (
 	"math"
+	"math/bits"
 	"slices"
 )
 
+// NaN is not <, > or == to anything, not even itself, so a float slice that
+// contains NaN breaks the comparison sorts and comes back in garbage order.
+// Use SortFloatsChecked if the input might have NaNs.
 type Ordered interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
 		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
@@ -12,8 +24,23 @@ type Ordered interface {
 		~string
 }
 
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Anything that can be converted to a float64
+type Number interface {
+	Integer | ~float32 | ~float64
+}
+
 const NumDigits = 10
 
+// QuickSort and MergeSort stop recursing once a range is this small and
+// finish it with a sorting network or InsertionSort, which are faster on
+// tiny ranges
+const InsertionSortCutoff = 12
+
 // Like selection sort, not optimized
 func SimpleSort[T Ordered](vec []T) {
 	// Can go to len(vec)-1 because no need for processing at last index
@@ -65,6 +92,22 @@ func BubbleSort[T Ordered](vec []T) {
 	}
 }
 
+// Bubble sort in two phases: compare (0,1), (2,3), ... then (1,2), (3,4), ...
+// No two pairs in a phase overlap, so each phase could run fully in parallel
+func OddEvenSort[T Ordered](vec []T) {
+	for sorted := false; !sorted; {
+		sorted = true
+		for phase := 0; phase < 2; phase++ {
+			for j := phase; j < len(vec)-1; j += 2 {
+				if vec[j] > vec[j+1] {
+					vec[j], vec[j+1] = vec[j+1], vec[j]
+					sorted = false
+				}
+			}
+		}
+	}
+}
+
 // Insert each new element to the sorted range in the left
 func InsertionSort[T Ordered](vec []T) {
 	// First element is already sorted
@@ -88,14 +131,57 @@ func MergeSort[T Ordered](vec []T) {
 }
 
 func mergeSortHelper[T Ordered](vec []T, tmp []T, start int, end int) {
-	if start >= end {
+	// InsertionSort and not smallSort, the networks aren't stable
+	if end-start+1 <= InsertionSortCutoff {
+		InsertionSort(vec[start : end+1])
 		return
 	}
 
 	mid := start + (end-start)/2
 	mergeSortHelper(vec, tmp, start, mid)
 	mergeSortHelper(vec, tmp, mid+1, end)
-	merge(vec, tmp, start, mid, end)
+	gallopMerge(vec, tmp, start, mid, end)
+}
+
+// Same merges as MergeSort but without the recursion. First every pair of
+// single elements is merged, then every pair of runs of 2, then 4, ...
+// The last run of a pass can be shorter, so any length works
+func MergeSortBottomUp[T Ordered](vec []T) {
+	n := len(vec)
+	if n <= 1 {
+		return
+	}
+
+	mergePasses(vec, nil)
+}
+
+// The passes of MergeSortBottomUp. If check isn't nil it's called once per
+// ContextCheckInterval elements merged, the first error it returns stops the
+// sort between two merges and is returned
+func mergePasses[T Ordered](vec []T, check func() error) error {
+	n := len(vec)
+	tmp := make([]T, n)
+	sinceCheck := 0
+	for width := 1; width < n; width *= 2 {
+		for start := 0; start < n-width; start += 2 * width {
+			mid := start + width - 1
+			end := min(start+2*width-1, n-1)
+			merge(vec, tmp, start, mid, end)
+
+			if check == nil {
+				continue
+			}
+			sinceCheck += end - start + 1
+			if sinceCheck >= ContextCheckInterval {
+				sinceCheck = 0
+				if err := check(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
 }
 
 func merge[T Ordered](vec []T, tmp []T, start int, mid int, end int) {
@@ -140,19 +226,31 @@ func QuickSort[T Ordered](vec []T) {
 	quickSortHelper(vec, 0, len(vec)-1)
 }
 
+// Only recurses into the smaller side and loops on the bigger one. The
+// smaller side is at most half the range, so the stack stays O(log n) even
+// when the pivots are terrible
 func quickSortHelper[T Ordered](vec []T, start int, end int) {
-	if start >= end {
-		return
+	for end-start+1 > InsertionSortCutoff {
+		pivot := partition(vec, start, end)
+		if pivot-start < end-pivot {
+			quickSortHelper(vec, start, pivot-1)
+			start = pivot + 1
+		} else {
+			quickSortHelper(vec, pivot+1, end)
+			end = pivot - 1
+		}
 	}
 
-	pivot := partition(vec, start, end)
-	quickSortHelper(vec, start, pivot-1)
-	quickSortHelper(vec, pivot+1, end)
+	smallSort(vec[start : end+1])
 }
 
 func partition[T Ordered](vec []T, start int, end int) int {
 	mid := start + (end-start)/2
-	pivotIndex := medianOfThree(vec, start, mid, end)
+	return partitionAt(vec, start, end, medianOfThree(vec, start, mid, end))
+}
+
+// Lomuto partition of vec[start:end+1] around vec[pivotIndex]
+func partitionAt[T Ordered](vec []T, start int, end int, pivotIndex int) int {
 	vec[pivotIndex], vec[end] = vec[end], vec[pivotIndex]
 
 	pivot := vec[end]
@@ -179,12 +277,128 @@ func medianOfThree[T Ordered](vec []T, i, j, k int) int {
 	}
 }
 
+// Below this many elements IntroSort just runs insertion sort
+const IntroSortThreshold = 16
+
+// QuickSort, but it keeps track of how deep the recursion went. Once it goes
+// past 2*log2(n) the pivots are clearly bad, so that range is finished with
+// HeapSort instead. That keeps the worst case at O(n log n)
+func IntroSort[T Ordered](vec []T) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	depthLimit := 2 * bits.Len(uint(len(vec)))
+	introSortHelper(vec, 0, len(vec)-1, depthLimit)
+}
+
+func introSortHelper[T Ordered](vec []T, start int, end int, depthLimit int) {
+	if end-start+1 <= IntroSortThreshold {
+		InsertionSort(vec[start : end+1])
+		return
+	}
+
+	if depthLimit == 0 {
+		HeapSort(vec[start : end+1])
+		return
+	}
+
+	pivot := partition(vec, start, end)
+	introSortHelper(vec, start, pivot-1, depthLimit-1)
+	introSortHelper(vec, pivot+1, end, depthLimit-1)
+}
+
+// QuickSort that splits into three parts: < pivot, == pivot and > pivot.
+// The middle part is already done, so lots of duplicates make it faster
+// instead of slower
+func ThreeWayQuickSort[T Ordered](vec []T) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	threeWayQuickSortHelper(vec, 0, len(vec)-1)
+}
+
+func threeWayQuickSortHelper[T Ordered](vec []T, start int, end int) {
+	if start >= end {
+		return
+	}
+
+	mid := start + (end-start)/2
+	pivot := vec[medianOfThree(vec, start, mid, end)]
+	lt, gt := partition3(vec, start, end, pivot)
+
+	threeWayQuickSortHelper(vec, start, lt-1)
+	threeWayQuickSortHelper(vec, gt+1, end)
+}
+
+// Like Java's Arrays.sort: two pivots p <= q split the range into three
+// parts, < p, between p and q, and > q
+func DualPivotQuickSort[T Ordered](vec []T) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	dualPivotQuickSortHelper(vec, 0, len(vec)-1)
+}
+
+func dualPivotQuickSortHelper[T Ordered](vec []T, start int, end int) {
+	if end-start+1 <= InsertionSortCutoff {
+		smallSort(vec[start : end+1])
+		return
+	}
+
+	// Take the pivots from a third of the way in from both ends. Using the
+	// ends themselves would be quadratic on sorted input
+	third := (end - start) / 3
+	vec[start], vec[start+third] = vec[start+third], vec[start]
+	vec[end], vec[end-third] = vec[end-third], vec[end]
+	if vec[start] > vec[end] {
+		vec[start], vec[end] = vec[end], vec[start]
+	}
+	p, q := vec[start], vec[end]
+
+	// vec[start+1:lt] < p, vec[lt:i] is between p and q, vec[gt+1:end] > q
+	lt, i, gt := start+1, start+1, end-1
+	for i <= gt {
+		if vec[i] < p {
+			vec[i], vec[lt] = vec[lt], vec[i]
+			lt++
+		} else if vec[i] > q {
+			for vec[gt] > q && i < gt {
+				gt--
+			}
+			vec[i], vec[gt] = vec[gt], vec[i]
+			gt--
+			// what came from the right could still be < p
+			if vec[i] < p {
+				vec[i], vec[lt] = vec[lt], vec[i]
+				lt++
+			}
+		}
+		i++
+	}
+	lt--
+	gt++
+
+	// move the pivots into their final spots
+	vec[start], vec[lt] = vec[lt], vec[start]
+	vec[end], vec[gt] = vec[gt], vec[end]
+
+	dualPivotQuickSortHelper(vec, start, lt-1)
+	// if p == q everything in the middle equals them, nothing to do
+	if p < q {
+		dualPivotQuickSortHelper(vec, lt+1, gt-1)
+	}
+	dualPivotQuickSortHelper(vec, gt+1, end)
+}
+
 // Use a max-heap and then remove the first element one by one, put it at the end
 // Then fix the rest using heapify
 func HeapSort[T Ordered](vec []T) {
 	n := len(vec)
 	buildHeap(vec)
-	for i := n - 1; i >= 0; i-- {
+	for i := n - 1; i > 0; i-- {
 		vec[0], vec[i] = vec[i], vec[0]
 		heapify(vec, 0, i)
 	}
@@ -225,24 +439,52 @@ func GeneralCountingSort(vec []uint) {
 	}
 
 	max := slices.Max(vec)
+	if !countingRangeOK(max, len(vec)) {
+		PDQSort(vec)
+		return
+	}
 
-	counts := make([]uint, max+1)
+	counts := make([]int, max+1)
 	sorted := make([]uint, len(vec))
 
+	// int counters can count up to len(vec), so this doesn't fail in
+	// practice, see countingScatter
+	if !countingScatter(vec, sorted, counts) {
+		PDQSort(vec)
+		return
+	}
+
+	copy(vec, sorted)
+}
+
+// The stable scatter of GeneralCountingSort: counts vec, turns the counts
+// into running totals and places every value in sorted. counts has to be
+// zeroed and max+1 long. It is generic over the counter type so the overflow
+// checks can be tried out with a tiny one, if a count or a running total
+// doesn't fit in C it returns false and sorted is garbage. vec is never
+// written to
+func countingScatter[C Integer](vec []uint, sorted []uint, counts []C) bool {
 	for _, val := range vec {
-		counts[val]++
+		next := counts[val] + 1
+		if next <= counts[val] {
+			return false
+		}
+		counts[val] = next
 	}
 
 	for i := 1; i < len(counts); i++ {
-		counts[i] += counts[i-1]
+		sum := counts[i] + counts[i-1]
+		if sum < counts[i-1] {
+			return false
+		}
+		counts[i] = sum
 	}
 
 	for i := len(vec) - 1; i >= 0; i-- {
-		sorted[counts[vec[i]]-1] = vec[i]
 		counts[vec[i]]--
+		sorted[counts[vec[i]]] = vec[i]
 	}
-
-	copy(vec, sorted)
+	return true
 }
 
 func IntegerCountingSort(vec []uint) {
@@ -251,7 +493,12 @@ func IntegerCountingSort(vec []uint) {
 	}
 
 	max := slices.Max(vec)
-	counts := make([]uint, max+1)
+	if !countingRangeOK(max, len(vec)) {
+		PDQSort(vec)
+		return
+	}
+
+	counts := make([]int, max+1)
 
 	for _, val := range vec {
 		counts[val]++
@@ -268,35 +515,194 @@ func IntegerCountingSort(vec []uint) {
 	}
 }
 
-func IntRadixSort(vec []uint) {
+// Same as IntegerCountingSort but hands back the counts array so it can be
+// used as a histogram, i.e., histogram[val] is how many times val appeared.
+// Its length is max+1, so if the max value is too big for a counting sort
+// (see countingRangeOK) vec is sorted with PDQSort and nil is returned.
+func CountingSortWithHistogram(vec []uint) []uint {
+	if len(vec) == 0 {
+		return nil
+	}
+
+	max := slices.Max(vec)
+	if !countingRangeOK(max, len(vec)) {
+		PDQSort(vec)
+		return nil
+	}
+
+	histogram := make([]uint, max+1)
+
+	for _, val := range vec {
+		histogram[val]++
+	}
+
+	index := 0
+	for val, count := range histogram {
+		for c := uint(0); c < count; c++ {
+			vec[index] = uint(val)
+			index++
+		}
+	}
+
+	return histogram
+}
+
+// Counting sorts allocate max+1 counters, a single 1<<40 in the slice would
+// mean a terabyte. They fall back to PDQSort unless max+1 is at most
+// CountingRangeFactor*len(vec), or MinCountingRange for small slices.
+// PDQSort and not QuickSort, counting sort input tends to be full of
+// duplicates, which QuickSort's partition is quadratic on
+const (
+	CountingRangeFactor = 16
+	MinCountingRange    = 1 << 16
+)
+
+func countingRangeOK(max uint, n int) bool {
+	// max+1 would overflow to 0
+	if max == ^uint(0) {
+		return false
+	}
+	return max+1 <= countingRangeLimit(n)
+}
+
+// How many counters a counting sort of n elements is allowed
+func countingRangeLimit(n int) uint {
+	limit := uint(n) * CountingRangeFactor
+	if limit/CountingRangeFactor != uint(n) || limit < MinCountingRange {
+		limit = MinCountingRange
+	}
+	return limit
+}
+
+// GeneralCountingSort for hot loops. counts and scratch are reused instead of
+// allocated, and only grown if they are too small. Pass in what the previous
+// call returned and once they are big enough there are no allocations at all
+func CountingSortBuffer(vec []uint, counts, scratch []uint) ([]uint, []uint) {
+	if len(vec) <= 1 {
+		return counts, scratch
+	}
+
+	max := slices.Max(vec)
+	if !countingRangeOK(max, len(vec)) {
+		PDQSort(vec)
+		return counts, scratch
+	}
+
+	if uint(cap(counts)) < max+1 {
+		counts = make([]uint, max+1)
+	}
+	counts = counts[:max+1]
+	clear(counts)
+
+	if cap(scratch) < len(vec) {
+		scratch = make([]uint, len(vec))
+	}
+	scratch = scratch[:len(vec)]
+
+	// uint is as wide as int, so the counters can't overflow either
+	if !countingScatter(vec, scratch, counts) {
+		PDQSort(vec)
+		return counts, scratch
+	}
+
+	copy(vec, scratch)
+	return counts, scratch
+}
+
+// GeneralCountingSort but largest first. The prefix sums run from the top
+// value down, so the placement loop puts the biggest values at the front in
+// the same single pass. Stable like GeneralCountingSort: equal values keep
+// their input order, they aren't reversed the way sorting ascending and then
+// calling Reverse would
+func CountingSortDesc(vec []uint) {
 	if len(vec) <= 1 {
 		return
 	}
 
+	max := slices.Max(vec)
+	if !countingRangeOK(max, len(vec)) {
+		PDQSort(vec)
+		Reverse(vec)
+		return
+	}
+
+	counts := make([]int, max+1)
+	sorted := make([]uint, len(vec))
+
+	for _, val := range vec {
+		counts[val]++
+	}
+
+	for i := len(counts) - 2; i >= 0; i-- {
+		counts[i] += counts[i+1]
+	}
+
+	for i := len(vec) - 1; i >= 0; i-- {
+		sorted[counts[vec[i]]-1] = vec[i]
+		counts[vec[i]]--
+	}
+
+	copy(vec, sorted)
+}
+
+func IntRadixSort(vec []uint) {
+	intRadixSort(vec, false)
+}
+
+// IntRadixSort but largest first. Every pass puts the digits in descending
+// order directly instead of sorting ascending and reversing at the end, so
+// it is the same number of passes. Each pass is still stable, elements with
+// the same digit keep the order the previous pass left them in, which is
+// what makes LSD radix sort work in either direction
+func IntRadixSortDesc(vec []uint) {
+	intRadixSort(vec, true)
+}
+
+// Sorts vec and returns how many digit passes it took
+func intRadixSort(vec []uint, desc bool) int {
+	if len(vec) <= 1 {
+		return 0
+	}
+
 	max := slices.Max(vec)
 	var exp uint = 1
+	passes := 0
 
 	for (max / exp) > 0 {
-		radixIntCountSort(vec, exp)
+		radixIntCountSort(vec, exp, NumDigits, desc)
+		passes++
+
+		// exp*10 would wrap around for a max close to the top of uint and
+		// then the loop would keep doing passes with a garbage exp
+		if exp > max/NumDigits {
+			break
+		}
 		exp *= 10
 	}
+	return passes
 }
 
-func radixIntCountSort(vec []uint, exp uint) {
+func radixIntCountSort(vec []uint, exp uint, base uint, desc bool) {
 	output := make([]uint, len(vec))
-	counts := make([]uint, NumDigits)
+	counts := make([]int, base)
 
 	for i := 0; i < len(vec); i++ {
-		bucket := (vec[i] / exp) % NumDigits
+		bucket := (vec[i] / exp) % base
+		if desc {
+			bucket = base - 1 - bucket
+		}
 		counts[bucket]++
 	}
 
-	for i := uint(1); i < NumDigits; i++ {
+	for i := uint(1); i < base; i++ {
 		counts[i] += counts[i-1]
 	}
 
 	for i := len(vec) - 1; i >= 0; i-- {
-		bucket := (vec[i] / exp) % NumDigits
+		bucket := (vec[i] / exp) % base
+		if desc {
+			bucket = base - 1 - bucket
+		}
 		output[counts[bucket]-1] = vec[i]
 		counts[bucket]--
 	}
@@ -328,11 +734,18 @@ func LessEfficientRadixSort(vec []uint) {
 			radixArray[i] = nil
 		}
 
+		// same overflow guard as IntRadixSort
+		if divisor > max/NumDigits {
+			break
+		}
 		divisor *= 10
 	}
 }
 
-// REQUIRES: ASCII strings
+// LSD radix sort on the bytes of the strings, so the order is the same as
+// comparing strings with <. Past the end of a string counts as smaller than
+// any byte, that way "app" goes before "apple". Stable, equal strings keep
+// their order
 func StringRadixSort(vec []string) {
 	if len(vec) <= 1 {
 		return
@@ -352,12 +765,14 @@ func StringRadixSort(vec []string) {
 
 func radixStringCountSort(vec []string, curIdx int) {
 	output := make([]string, len(vec))
-	counts := make([]uint, 129)
+	// bucket 0 is for the strings that are too short, every byte value gets
+	// its own bucket after that
+	counts := make([]int, 257)
 
-	var bucket uint8
+	var bucket int
 	for i := 0; i < len(vec); i++ {
 		if curIdx < len(vec[i]) {
-			bucket = uint8(vec[i][curIdx]) + 1
+			bucket = int(vec[i][curIdx]) + 1
 		} else {
 			bucket = 0 // for shorter strings
 		}
@@ -370,7 +785,7 @@ func radixStringCountSort(vec []string, curIdx int) {
 
 	for i := len(vec) - 1; i >= 0; i-- {
 		if curIdx < len(vec[i]) {
-			bucket = uint8(vec[i][curIdx]) + 1
+			bucket = int(vec[i][curIdx]) + 1
 		} else {
 			bucket = 0 // for shorter strings
 		}
@@ -382,29 +797,22 @@ func radixStringCountSort(vec []string, curIdx int) {
 }
 
 func BucketSort(vec []float64) {
+	bucketSort(vec)
+}
+
+// Sorts vec and returns how many elements went into each bucket
+func bucketSort(vec []float64) []int {
 	if len(vec) <= 1 {
-		return
+		return []int{len(vec)}
 	}
 
-	min := math.Inf(1)
-	max := math.Inf(-1)
-
-	for _, val := range vec {
-		// need to if's because edge case when first element is the max!
-
-		if val < min {
-			min = val
-		}
-
-		if val > max {
-			max = val
-		}
-	}
+	// can't fail, vec isn't empty
+	min, max, _ := MinMax(vec)
 
 	// edge case when no need for buckets! simply quicksort.
 	if max == min {
 		QuickSort(vec)
-		return
+		return []int{len(vec)}
 	}
 
 	numBuckets := int((max-min)/math.Sqrt(float64(len(vec)))) + 1 // need +1 here!
@@ -416,10 +824,12 @@ func BucketSort(vec []float64) {
 	}
 
 	output := make([]float64, len(vec))
+	counts := make([]int, numBuckets)
 	k := 0
 
 	for i := 0; i < len(buckets); i++ {
 		QuickSort(buckets[i])
+		counts[i] = len(buckets[i])
 		for _, val := range buckets[i] {
 			output[k] = val
 			k++
@@ -427,4 +837,5 @@ func BucketSort(vec []float64) {
 	}
 
 	copy(vec, output)
+	return counts
 }
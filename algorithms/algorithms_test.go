@@ -0,0 +1,759 @@
+package algorithms
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// -0 and +0 compare equal but can be told apart, so they show if a sort kept
+// equal elements in their input order
+func TestMergeSortStable(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+
+	tests := []struct {
+		name string
+		sort func([]float64)
+	}{
+		{"MergeSort", MergeSort[float64]},
+		{"MergeSortBottomUp", MergeSortBottomUp[float64]},
+	}
+
+	for _, tt := range tests {
+		for _, n := range []int{2, 5, InsertionSortCutoff, 100, 1000} {
+			vec := make([]float64, n)
+			signs := make([]bool, 0, n)
+			for i := range vec {
+				if i%3 == 0 {
+					vec[i] = negZero
+				}
+				// a few non zeros so there is something to merge around
+				if i%7 == 0 {
+					vec[i] = float64(n - i)
+				}
+				if vec[i] == 0 {
+					signs = append(signs, math.Signbit(vec[i]))
+				}
+			}
+
+			tt.sort(vec)
+
+			var got []bool
+			for _, val := range vec {
+				if val == 0 {
+					got = append(got, math.Signbit(val))
+				}
+			}
+			if !slices.IsSorted(vec) || !slices.Equal(got, signs) {
+				t.Errorf("%s n=%d: zeros out of their input order", tt.name, n)
+			}
+		}
+	}
+}
+
+// Every exported sort has to be a no-op on nil, empty and single element
+// input. Only checks that nothing panics and the element is still there
+func TestEmptyInputs(t *testing.T) {
+	byInt := ByField(func(x int) int { return x })
+
+	intSorts := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"SimpleSort", SimpleSort[int]},
+		{"SelectionSort", SelectionSort[int]},
+		{"BubbleSort", BubbleSort[int]},
+		{"OddEvenSort", OddEvenSort[int]},
+		{"InsertionSort", InsertionSort[int]},
+		{"MergeSort", MergeSort[int]},
+		{"MergeSortBottomUp", MergeSortBottomUp[int]},
+		{"SymMergeSort", SymMergeSort[int]},
+		{"BlockMergeSort", BlockMergeSort[int]},
+		{"QuickSort", QuickSort[int]},
+		{"IntroSort", IntroSort[int]},
+		{"ThreeWayQuickSort", ThreeWayQuickSort[int]},
+		{"DualPivotQuickSort", DualPivotQuickSort[int]},
+		{"PDQSort", PDQSort[int]},
+		{"HeapSort", HeapSort[int]},
+		{"HeapSortDesc", HeapSortDesc[int]},
+		{"DaryHeapSort", func(v []int) { DaryHeapSort(v, 4) }},
+		{"BitonicSort", BitonicSort[int]},
+		{"SampleSort", SampleSort[int]},
+		{"Sort", Sort[int]},
+		{"SortAdaptive", SortAdaptive[int]},
+		{"SortAdaptiveWithThreshold", func(v []int) { SortAdaptiveWithThreshold(v, 0) }},
+		{"SortRange", func(v []int) { SortRange(v, 0, len(v)) }},
+		{"SortAndReport", func(v []int) { SortAndReport(v) }},
+		{"SortBudget", func(v []int) { SortBudget(v, 0) }},
+		{"InsertSorted", func(v []int) { InsertSorted(v, 0) }},
+		{"SortLimited", func(v []int) { SortLimited(v, 1) }},
+		{"SortWithSentinel", func(v []int) { SortWithSentinel(v, 0, true) }},
+		{"SortWith", func(v []int) { SortWith(v, Options{}) }},
+		{"SortWith parallel", func(v []int) { SortWith(v, Options{Parallel: true, Descending: true}) }},
+		{"SortWith stable", func(v []int) { SortWith(v, Options{Stable: true}) }},
+		{"SortContext", func(v []int) { SortContext(context.Background(), v) }},
+		{"SortDeadline", func(v []int) { SortDeadline(v, time.Minute) }},
+		{"QuickSortPivot", func(v []int) { QuickSortPivot(v, PivotRandom) }},
+		{"QuickSortLeaf", func(v []int) { QuickSortLeaf(v, 4, nil) }},
+		{"RadixSort", RadixSort[int]},
+		{"RadixSortByKey", func(v []int) { RadixSortByKey(v, func(x int) uint64 { return uint64(x) }) }},
+		{"FrequencySort", FrequencySort[int]},
+		{"FrequencySortFunc", func(v []int) { FrequencySortFunc(v, byInt) }},
+		{"InsertionSortCmp", func(v []int) { InsertionSortCmp(v, byInt) }},
+		{"QuickSortCmp", func(v []int) { QuickSortCmp(v, byInt) }},
+		{"MergeSortCmp", func(v []int) { MergeSortCmp(v, byInt) }},
+		{"SortFuncErr", func(v []int) {
+			SortFuncErr(v, func(a, b int) (int, error) { return byInt(a, b), nil })
+		}},
+		{"SortColumns", func(v []int) { SortColumns(v, []ColumnSpec[int]{{Compare: byInt}}) }},
+		{"BucketSortFunc", func(v []int) { BucketSortFunc(v, func(int) float64 { return 0 }, byInt) }},
+		{"CountingSortKeyed", func(v []int) { CountingSortKeyed(v, func(x int) int { return x }, 5) }},
+		{"StablePartition", func(v []int) { StablePartition(v, func(x int) bool { return x > 0 }) }},
+		{"SortByParallelKeys", func(v []int) { SortByParallelKeys(v, slices.Clone(v)) }},
+		{"SortBytesKey", func(v []int) { SortBytesKey(v, func(int) []byte { return nil }) }},
+		{"BuildMaxHeap", BuildMaxHeap[int]},
+		{"BuildMinHeap", BuildMinHeap[int]},
+		{"Partition3Way", func(v []int) { Partition3Way(v, 0) }},
+		{"SortUnique", func(v []int) { SortUnique(v) }},
+		{"SortUniqueFunc", func(v []int) { SortUniqueFunc(v, byInt) }},
+		{"SortedIter", func(v []int) {
+			for range SortedIter(v) {
+			}
+		}},
+	}
+
+	for _, tt := range intSorts {
+		for _, in := range [][]int{nil, {}, {5}} {
+			t.Run(tt.name, func(t *testing.T) {
+				vec := slices.Clone(in)
+				tt.sort(vec)
+				if !slices.Equal(vec, in) {
+					t.Errorf("%s(%v) changed it to %v", tt.name, in, vec)
+				}
+			})
+		}
+	}
+
+	uintSorts := []struct {
+		name string
+		sort func([]uint)
+	}{
+		{"GeneralCountingSort", GeneralCountingSort},
+		{"IntegerCountingSort", IntegerCountingSort},
+		{"CountingSortWithHistogram", func(v []uint) { CountingSortWithHistogram(v) }},
+		{"CountingSortBuffer", func(v []uint) { CountingSortBuffer(v, nil, nil) }},
+		{"CountingSortDesc", CountingSortDesc},
+		{"IntRadixSort", IntRadixSort},
+		{"IntRadixSortDesc", IntRadixSortDesc},
+		{"IntRadixSortConfig", func(v []uint) { IntRadixSortConfig(v, RadixConfig{Base: 256}) }},
+		{"LessEfficientRadixSort", LessEfficientRadixSort},
+		{"ParallelCountingSort", ParallelCountingSort},
+		{"ParallelRadixSort", ParallelRadixSort},
+	}
+
+	for _, tt := range uintSorts {
+		for _, in := range [][]uint{nil, {}, {0}, {5}} {
+			t.Run(tt.name, func(t *testing.T) {
+				vec := slices.Clone(in)
+				tt.sort(vec)
+				if !slices.Equal(vec, in) {
+					t.Errorf("%s(%v) changed it to %v", tt.name, in, vec)
+				}
+			})
+		}
+	}
+
+	floatSorts := []struct {
+		name string
+		sort func([]float64)
+	}{
+		{"BucketSort", BucketSort},
+		{"BucketSortStats", func(v []float64) { BucketSortStats(v) }},
+		{"BucketSortBounds", func(v []float64) { BucketSortBounds(v, []float64{0, 1}) }},
+		{"ParallelBucketSort", ParallelBucketSort},
+		{"SortFloatsChecked", func(v []float64) { SortFloatsChecked(v) }},
+		{"Float32RadixSort", func(v []float64) {
+			f := make([]float32, len(v))
+			for i := range v {
+				f[i] = float32(v[i])
+			}
+			Float32RadixSort(f)
+		}},
+	}
+
+	for _, tt := range floatSorts {
+		for _, in := range [][]float64{nil, {}, {1.5}} {
+			t.Run(tt.name, func(t *testing.T) {
+				vec := slices.Clone(in)
+				tt.sort(vec)
+				if !slices.Equal(vec, in) {
+					t.Errorf("%s(%v) changed it to %v", tt.name, in, vec)
+				}
+			})
+		}
+	}
+
+	stringSorts := []struct {
+		name string
+		sort func([]string)
+	}{
+		{"StringRadixSort", StringRadixSort},
+		{"AmericanFlagSort", AmericanFlagSort},
+		{"StringSortByLength", StringSortByLength},
+		{"NaturalSort", NaturalSort},
+		{"StringSortWith", func(v []string) { StringSortWith(v, strings.Compare) }},
+	}
+
+	for _, tt := range stringSorts {
+		for _, in := range [][]string{nil, {}, {""}, {"x"}} {
+			t.Run(tt.name, func(t *testing.T) {
+				vec := slices.Clone(in)
+				tt.sort(vec)
+				if !slices.Equal(vec, in) {
+					t.Errorf("%s(%v) changed it to %v", tt.name, in, vec)
+				}
+			})
+		}
+	}
+
+	// the rest take something other than a plain slice
+	others := []struct {
+		name string
+		run  func()
+	}{
+		{"SortInt8", func() { SortInt8(nil); SortInt8([]int8{}) }},
+		{"RuneSort", func() { RuneSort(nil); RuneSort([]rune{}) }},
+		{"SortRunes", func() { SortRunes("") }},
+		{"SortLex", func() { SortLex[int](nil); SortLex([][]int{}); SortLex([][]int{nil}) }},
+		{"SortByteArrays16", func() { SortByteArrays16(nil); SortByteArrays16([][16]byte{}) }},
+		{"SortTimes", func() { SortTimes(nil); SortTimesDesc([]time.Time{}) }},
+		{"SortPointers", func() { SortPointers[int](nil, NilsFirst); SortPointers([]*int{nil}, NilsLast) }},
+		{"SortList", func() { SortList[int](nil); SortListFunc(nil, byInt) }},
+		{"CopySort", func() { CopySort[int](nil); CopySortFunc([]int{}, byInt) }},
+		{"SortFiltered", func() { SortFiltered[int](nil, func(int) bool { return true }) }},
+		{"SortAndFindDuplicates", func() { SortAndFindDuplicates[int](nil) }},
+		{"SortAndGroup", func() { SortAndGroup[int](nil); SortAndGroupBy([]int{}, func(x int) int { return x }) }},
+		{"SortWithIndices", func() { SortWithIndices[int](nil); Rank([]int{}) }},
+		{"CountInversions", func() { CountInversions[int](nil) }},
+		{"SortSeq", func() {
+			for range SortSeq(slices.Values([]int(nil))) {
+			}
+			for range SortSeqFunc(slices.Values([]int{}), byInt) {
+			}
+		}},
+		{"SlidingMedian", func() { SlidingMedian([]int(nil), 3) }},
+		{"maps", func() {
+			SortedKeys(map[int]int(nil))
+			SortedPairs(map[int]int{})
+			SortedByValue(map[int]int(nil))
+			SortedByValueDesc(map[int]int(nil))
+		}},
+		{"SortChannel", func() {
+			in := make(chan int)
+			close(in)
+			SortChannel(context.Background(), in)
+		}},
+		{"TopKChannel", func() {
+			in := make(chan int)
+			close(in)
+			TopKChannel(context.Background(), in, 3)
+		}},
+		{"MergeKChannels", func() {
+			for range MergeKChannels[int]() {
+			}
+		}},
+		{"ExternalSort", func() {
+			if err := ExternalSort(strings.NewReader(""), io.Discard, ExternalSortOptions{}); err != nil {
+				t.Error(err)
+			}
+		}},
+		{"ExternalTopK", func() {
+			if _, err := ExternalTopK(strings.NewReader(""), 3); err != nil {
+				t.Error(err)
+			}
+		}},
+	}
+
+	for _, tt := range others {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run()
+		})
+	}
+}
+
+// uint8 and int8 counters stand in for counters that are too small for
+// len(vec), the overflow has to be noticed instead of wrapping around
+func TestCountingScatterOverflow(t *testing.T) {
+	repeat := func(val uint, n int) []uint { return slices.Repeat([]uint{val}, n) }
+
+	tests := []struct {
+		name    string
+		vec     []uint
+		scatter func(vec, sorted []uint) bool
+		want    bool
+	}{
+		{"uint8 fits", repeat(1, 255), func(v, s []uint) bool { return countingScatter(v, s, make([]uint8, 2)) }, true},
+		{"uint8 count overflows", repeat(1, 256), func(v, s []uint) bool { return countingScatter(v, s, make([]uint8, 2)) }, false},
+		{"uint8 total overflows", append(repeat(0, 200), repeat(1, 100)...), func(v, s []uint) bool { return countingScatter(v, s, make([]uint8, 2)) }, false},
+		{"int8 fits", append(repeat(0, 60), repeat(2, 67)...), func(v, s []uint) bool { return countingScatter(v, s, make([]int8, 3)) }, true},
+		{"int8 total overflows", append(repeat(0, 64), repeat(2, 64)...), func(v, s []uint) bool { return countingScatter(v, s, make([]int8, 3)) }, false},
+		{"int", append(repeat(0, 200), repeat(1, 100)...), func(v, s []uint) bool { return countingScatter(v, s, make([]int, 2)) }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Shuffle(tt.vec, rand.New(rand.NewSource(1)))
+			in := slices.Clone(tt.vec)
+			sorted := make([]uint, len(tt.vec))
+
+			got := tt.scatter(tt.vec, sorted)
+			if got != tt.want {
+				t.Fatalf("countingScatter = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(tt.vec, in) {
+				t.Errorf("vec was written to")
+			}
+			if got && !slices.Equal(sorted, slices.Sorted(slices.Values(in))) {
+				t.Errorf("sorted = %v", sorted)
+			}
+		})
+	}
+}
+
+// The decimal radix sorts used to multiply their divisor by 10 past the top
+// of uint when the max was big enough, and kept doing passes on garbage
+func TestRadixSortHugeValues(t *testing.T) {
+	tests := []struct {
+		name string
+		sort func([]uint)
+	}{
+		{"IntRadixSort", IntRadixSort},
+		{"IntRadixSortDesc", func(v []uint) { IntRadixSortDesc(v); slices.Reverse(v) }},
+		{"LessEfficientRadixSort", LessEfficientRadixSort},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	vec := make([]uint, 500)
+	for i := range vec {
+		vec[i] = uint(rng.Uint64())
+	}
+	vec = append(vec, ^uint(0), ^uint(0)-1, 0, 1, 10_000_000_000_000_000_000)
+	want := slices.Sorted(slices.Values(vec))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Clone(vec)
+			tt.sort(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("%s didn't sort values close to the max uint", tt.name)
+			}
+		})
+	}
+}
+
+func TestCountingSortBuffer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var counts, scratch []uint
+
+	// the buffers grow as needed, starting from nothing
+	for _, n := range []int{0, 1, 10, 1000, 50, 5000} {
+		vec := make([]uint, n)
+		for i := range vec {
+			vec[i] = uint(rng.Intn(n*2 + 1))
+		}
+		want := slices.Sorted(slices.Values(vec))
+
+		counts, scratch = CountingSortBuffer(vec, counts, scratch)
+		if !slices.Equal(vec, want) {
+			t.Fatalf("n=%d: CountingSortBuffer didn't sort", n)
+		}
+	}
+}
+
+func TestCountingSortBufferAllocs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	input := make([]uint, 10_000)
+	for i := range input {
+		input[i] = uint(rng.Intn(1000))
+	}
+
+	vec := make([]uint, len(input))
+	counts, scratch := make([]uint, 1000), make([]uint, len(input))
+	allocs := testing.AllocsPerRun(100, func() {
+		copy(vec, input)
+		counts, scratch = CountingSortBuffer(vec, counts, scratch)
+	})
+
+	if allocs != 0 {
+		t.Errorf("CountingSortBuffer with big enough buffers made %v allocations, want 0", allocs)
+	}
+	if !slices.IsSorted(vec) {
+		t.Errorf("CountingSortBuffer didn't sort")
+	}
+}
+
+func TestCountingSortWithHistogram(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := make([]uint, 5000)
+	for i := range random {
+		random[i] = uint(rng.Intn(300))
+	}
+
+	tests := []struct {
+		name   string
+		vec    []uint
+		hasNil bool
+	}{
+		{"empty", nil, true},
+		{"single", []uint{3}, false},
+		{"zeros", []uint{0, 0, 0}, false},
+		{"gaps", []uint{9, 0, 9, 4, 0, 9}, false},
+		{"random", random, false},
+		// far too big a range for a counting sort, it falls back to PDQSort
+		{"huge max", []uint{1 << 40, 3, 1, 3}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			histogram := CountingSortWithHistogram(vec)
+
+			if !slices.Equal(vec, slices.Sorted(slices.Values(tt.vec))) {
+				t.Fatalf("CountingSortWithHistogram didn't sort: %v", vec)
+			}
+			if tt.hasNil {
+				if histogram != nil {
+					t.Errorf("histogram = %v, want nil", histogram)
+				}
+				return
+			}
+
+			if want := int(slices.Max(vec)) + 1; len(histogram) != want {
+				t.Errorf("len(histogram) = %d, want max+1 = %d", len(histogram), want)
+			}
+			var sum uint
+			for _, c := range histogram {
+				sum += c
+			}
+			if sum != uint(len(vec)) {
+				t.Errorf("histogram sums to %d, want %d", sum, len(vec))
+			}
+
+			// the sorted slice is one run per value, as long as its count
+			i := 0
+			for val, count := range histogram {
+				for c := uint(0); c < count; c++ {
+					if vec[i] != uint(val) {
+						t.Fatalf("vec[%d] = %d, histogram says %d", i, vec[i], val)
+					}
+					i++
+				}
+			}
+		})
+	}
+}
+
+// Every size from empty to a few times InsertionSortCutoff, so the ranges
+// handed to the cutoff are hit at, below and just above it
+func TestInsertionSortCutoffSizes(t *testing.T) {
+	sorts := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"QuickSort", QuickSort[int]},
+		{"MergeSort", MergeSort[int]},
+		{"DualPivotQuickSort", DualPivotQuickSort[int]},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, s := range sorts {
+		for n := 0; n <= 4*InsertionSortCutoff+1; n++ {
+			for _, in := range [][]int{RandomInts(n, rng), ReverseSortedInts(n), FewUniqueInts(n, rng)} {
+				vec := slices.Clone(in)
+				s.sort(vec)
+				if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+					t.Fatalf("%s(%v) = %v", s.name, in, vec)
+				}
+			}
+		}
+	}
+}
+
+// QuickSort and MergeSort as they'd be without the cutoff, recursing down to
+// single elements, for the benchmark to compare against
+func quickSortNoCutoff(vec []int, start, end int) {
+	if start < end {
+		pivot := partition(vec, start, end)
+		quickSortNoCutoff(vec, start, pivot-1)
+		quickSortNoCutoff(vec, pivot+1, end)
+	}
+}
+
+func mergeSortNoCutoff(vec, tmp []int, start, end int) {
+	if start < end {
+		mid := start + (end-start)/2
+		mergeSortNoCutoff(vec, tmp, start, mid)
+		mergeSortNoCutoff(vec, tmp, mid+1, end)
+		gallopMerge(vec, tmp, start, mid, end)
+	}
+}
+
+func BenchmarkInsertionSortCutoff(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"QuickSort", QuickSort[int]},
+		{"QuickSort without cutoff", func(v []int) { quickSortNoCutoff(v, 0, len(v)-1) }},
+		{"MergeSort", MergeSort[int]},
+		{"MergeSort without cutoff", func(v []int) { mergeSortNoCutoff(v, make([]int, len(v)), 0, len(v)-1) }},
+	}
+
+	input := RandomInts(1_000_000, rand.New(rand.NewSource(BenchmarkSeed)))
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			vec := make([]int, len(input))
+			for i := 0; i < b.N; i++ {
+				copy(vec, input)
+				bm.sort(vec)
+			}
+		})
+	}
+}
+
+// With a tiny max stack a recursion that goes O(n) deep crashes the test
+// binary. All equal input is QuickSort's worst case, every partition puts
+// everything on one side, and sorted input is the classic big one
+func TestQuickSortStackDepth(t *testing.T) {
+	defer debug.SetMaxStack(debug.SetMaxStack(256 << 10))
+
+	tests := []struct {
+		name string
+		vec  []int
+	}{
+		{"all equal", make([]int, 20_000)},
+		{"sorted", SortedInts(5_000_000)},
+		{"reverse", ReverseSortedInts(5_000_000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			QuickSort(tt.vec)
+			if !slices.IsSorted(tt.vec) {
+				t.Errorf("QuickSort didn't sort")
+			}
+
+			vec := slices.Clone(tt.vec[:min(len(tt.vec), 20_000)])
+			QuickSortCmp(vec, cmp.Compare[int])
+			if !slices.IsSorted(vec) {
+				t.Errorf("QuickSortCmp didn't sort")
+			}
+		})
+	}
+}
+
+func TestMergeSortBottomUpMatchesMergeSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 7, 8, 9, 15, 16, 17, 100, 127, 128, 129, 1000, 1023, 1024, 1025, 4099} {
+		for _, in := range [][]int{RandomInts(n, rng), FewUniqueInts(n, rng), ReverseSortedInts(n)} {
+			bottomUp, topDown := slices.Clone(in), slices.Clone(in)
+			MergeSortBottomUp(bottomUp)
+			MergeSort(topDown)
+			if !slices.Equal(bottomUp, topDown) || !slices.IsSorted(bottomUp) {
+				t.Fatalf("n=%d: MergeSortBottomUp = %v, MergeSort = %v", n, bottomUp, topDown)
+			}
+		}
+	}
+}
+
+// A single huge value would mean a counts array of terabytes, max == MaxUint
+// doesn't even have a max+1. Both must go to the PDQSort fallback
+func TestCountingSortHugeMax(t *testing.T) {
+	sorts := []struct {
+		name string
+		sort func([]uint)
+	}{
+		{"GeneralCountingSort", GeneralCountingSort},
+		{"IntegerCountingSort", IntegerCountingSort},
+		{"CountingSortDesc", func(v []uint) { CountingSortDesc(v); slices.Reverse(v) }},
+		{"CountingSortWithHistogram", func(v []uint) { CountingSortWithHistogram(v) }},
+		{"CountingSortBuffer", func(v []uint) { CountingSortBuffer(v, nil, nil) }},
+		{"ParallelCountingSort", ParallelCountingSort},
+	}
+	inputs := map[string][]uint{
+		"1<<40":        {5, 1 << 40, 3, 0, 3},
+		"max uint":     {5, ^uint(0), 3, 0, 3},
+		"max uint x2":  {^uint(0), 2, ^uint(0)},
+		"just too big": append(make([]uint, 10), MinCountingRange),
+		"big slice":    append(make([]uint, 100_000), 1<<40),
+	}
+
+	for _, s := range sorts {
+		for name, in := range inputs {
+			vec := slices.Clone(in)
+			s.sort(vec)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+				t.Errorf("%s on %s = %v", s.name, name, vec)
+			}
+		}
+	}
+}
+
+func TestCountingRangeOK(t *testing.T) {
+	tests := []struct {
+		max  uint
+		n    int
+		want bool
+	}{
+		{0, 1, true},
+		{MinCountingRange - 1, 1, true},
+		{MinCountingRange, 1, false},
+		{CountingRangeFactor*100_000 - 1, 100_000, true},
+		{CountingRangeFactor * 100_000, 100_000, false},
+		{1 << 40, 1000, false},
+		{^uint(0), 1 << 20, false},
+	}
+
+	for _, tt := range tests {
+		if got := countingRangeOK(tt.max, tt.n); got != tt.want {
+			t.Errorf("countingRangeOK(%d, %d) = %v, want %v", tt.max, tt.n, got, tt.want)
+		}
+	}
+}
+
+// The dual pivot partition has the most corner cases around the pivots being
+// equal to each other or to their neighbours, so lots of small inputs drawn
+// from only a couple of values, just above the cutoff where it partitions
+func TestDualPivotQuickSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, distinct := range []int{1, 2, 3, 5, 1000} {
+		for i := 0; i < 2000; i++ {
+			n := InsertionSortCutoff + 1 + rng.Intn(100)
+			in := make([]int, n)
+			for j := range in {
+				in[j] = rng.Intn(distinct)
+			}
+
+			vec := slices.Clone(in)
+			DualPivotQuickSort(vec)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+				t.Fatalf("DualPivotQuickSort(%v) = %v", in, vec)
+			}
+		}
+	}
+
+	for _, n := range []int{100, 10_000, 100_000} {
+		for name, in := range patternInputs(n, rng) {
+			vec := slices.Clone(in)
+			DualPivotQuickSort(vec)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+				t.Errorf("n=%d: DualPivotQuickSort didn't sort %s input", n, name)
+			}
+		}
+	}
+}
+
+func BenchmarkDualPivotQuickSort(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"DualPivotQuickSort", DualPivotQuickSort[int]},
+		{"QuickSort", QuickSort[int]},
+	}
+
+	input := RandomInts(1_000_000, rand.New(rand.NewSource(BenchmarkSeed)))
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			vec := make([]int, len(input))
+			for i := 0; i < b.N; i++ {
+				copy(vec, input)
+				bm.sort(vec)
+			}
+		})
+	}
+}
+
+func TestOddEvenSortMatchesMergeSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 16, 17, 100, 513} {
+		for _, in := range [][]int{RandomInts(n, rng), FewUniqueInts(n, rng), ReverseSortedInts(n), SortedInts(n)} {
+			oddEven, merge := slices.Clone(in), slices.Clone(in)
+			OddEvenSort(oddEven)
+			MergeSort(merge)
+			if !slices.Equal(oddEven, merge) {
+				t.Fatalf("n=%d: OddEvenSort(%v) = %v, MergeSort = %v", n, in, oddEven, merge)
+			}
+		}
+	}
+}
+
+// Only adjacent elements that are strictly out of order get swapped, so equal
+// elements never pass each other
+func TestOddEvenSortStable(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	negZero := math.Copysign(0, -1)
+	for i := 0; i < 100; i++ {
+		vec := make([]float64, rng.Intn(60))
+		for j := range vec {
+			vec[j] = []float64{negZero, 0, 1, -1}[rng.Intn(4)]
+		}
+		want := zeroSigns(vec)
+
+		OddEvenSort(vec)
+		if !slices.IsSorted(vec) || !slices.Equal(zeroSigns(vec), want) {
+			t.Fatalf("OddEvenSort = %v, not sorted or not stable", vec)
+		}
+	}
+}
+
+func TestDescNonComparisonSorts(t *testing.T) {
+	tests := []struct {
+		name string
+		sort func([]uint)
+		asc  func([]uint)
+	}{
+		{"CountingSortDesc", CountingSortDesc, GeneralCountingSort},
+		{"IntRadixSortDesc", IntRadixSortDesc, IntRadixSort},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	inputs := map[string][]uint{
+		"two":        {1, 2},
+		"duplicates": {3, 0, 3, 7, 0, 3, 12, 7},
+		"all equal":  {5, 5, 5, 5},
+		"zeros":      {0, 0, 1, 0},
+		"huge max":   {1, 1 << 62, 3, 2, 1 << 62},
+	}
+	for _, n := range []int{10, 100, 5000} {
+		random := make([]uint, n)
+		for i := range random {
+			random[i] = uint(rng.Intn(n * 3))
+		}
+		inputs[fmt.Sprintf("random %d", n)] = random
+	}
+
+	for _, tt := range tests {
+		for name, in := range inputs {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				want := slices.Clone(in)
+				tt.asc(want)
+				slices.Reverse(want)
+
+				vec := slices.Clone(in)
+				tt.sort(vec)
+				if !slices.Equal(vec, want) {
+					t.Errorf("%s(%v) = %v, want %v", tt.name, in, vec, want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// Shape of the generated input for RunBenchmark
+type InputPattern int
+
+const (
+	RandomInput InputPattern = iota
+	SortedInput
+	ReverseSortedInput
+	FewUniqueInput
+)
+
+// Values in a FewUniqueInput are drawn from [0, FewUniqueValues)
+const FewUniqueValues = 10
+
+// Seed used by RunBenchmark so runs are comparable with each other
+const BenchmarkSeed = 42
+
+func (p InputPattern) String() string {
+	switch p {
+	case RandomInput:
+		return "random"
+	case SortedInput:
+		return "sorted"
+	case ReverseSortedInput:
+		return "reverse-sorted"
+	case FewUniqueInput:
+		return "few-unique"
+	default:
+		return "unknown"
+	}
+}
+
+// Instrumented is optional, leave it nil if the algorithm has no
+// instrumented version
+type Algorithm struct {
+	Name         string
+	Sort         func(vec []int)
+	Instrumented func(vec []int) Stats
+}
+
+type BenchResult struct {
+	Algorithm string
+	N         int
+	Pattern   InputPattern
+	Elapsed   time.Duration
+	// Only filled in when the algorithm has an instrumented version
+	Stats        Stats
+	Instrumented bool
+}
+
+// Every comparison sort in the package that works on []int, plus
+// slices.Sort from the standard library as a baseline to compare against
+var Algorithms = []Algorithm{
+	{"SimpleSort", SimpleSort[int], SimpleSortInstrumented[int]},
+	{"SelectionSort", SelectionSort[int], SelectionSortInstrumented[int]},
+	{"BubbleSort", BubbleSort[int], BubbleSortInstrumented[int]},
+	{"InsertionSort", InsertionSort[int], InsertionSortInstrumented[int]},
+	{"MergeSort", MergeSort[int], MergeSortInstrumented[int]},
+	{"QuickSort", QuickSort[int], QuickSortInstrumented[int]},
+	{"HeapSort", HeapSort[int], HeapSortInstrumented[int]},
+	{"slices.Sort", slices.Sort[[]int], nil},
+}
+
+// n random values in [0, n)
+func RandomInts(n int, rng *rand.Rand) []int {
+	vec := make([]int, n)
+	for i := range vec {
+		vec[i] = rng.Intn(max(n, 1))
+	}
+	return vec
+}
+
+// 0, 1, ..., n-1
+func SortedInts(n int) []int {
+	vec := make([]int, n)
+	for i := range vec {
+		vec[i] = i
+	}
+	return vec
+}
+
+// n-1, n-2, ..., 0
+func ReverseSortedInts(n int) []int {
+	vec := make([]int, n)
+	for i := range vec {
+		vec[i] = n - 1 - i
+	}
+	return vec
+}
+
+// n random values in [0, FewUniqueValues)
+func FewUniqueInts(n int, rng *rand.Rand) []int {
+	vec := make([]int, n)
+	for i := range vec {
+		vec[i] = rng.Intn(FewUniqueValues)
+	}
+	return vec
+}
+
+func GenerateInput(n int, pattern InputPattern, rng *rand.Rand) []int {
+	switch pattern {
+	case SortedInput:
+		return SortedInts(n)
+	case ReverseSortedInput:
+		return ReverseSortedInts(n)
+	case FewUniqueInput:
+		return FewUniqueInts(n, rng)
+	default:
+		return RandomInts(n, rng)
+	}
+}
+
+// Sorts a generated input of size n and reports how long it took. If the
+// algorithm is instrumented it is run a second time on the same input to
+// get the counts, that way the counters don't skew the timing.
+func RunBenchmark(algo Algorithm, n int, pattern InputPattern) BenchResult {
+	rng := rand.New(rand.NewSource(BenchmarkSeed))
+	input := GenerateInput(n, pattern, rng)
+
+	vec := slices.Clone(input)
+	start := time.Now()
+	algo.Sort(vec)
+	elapsed := time.Since(start)
+
+	result := BenchResult{
+		Algorithm: algo.Name,
+		N:         n,
+		Pattern:   pattern,
+		Elapsed:   elapsed,
+	}
+
+	if algo.Instrumented != nil {
+		copy(vec, input)
+		result.Stats = algo.Instrumented(vec)
+		result.Instrumented = true
+	}
+
+	return result
+}
+
+// Times sort and slices.Sort on two copies of vec, vec itself isn't touched.
+// Each one gets its own fresh copy so neither runs on data the other already
+// pulled into the cache. Any entry of Sorters works as sort
+func CompareToStdlib[T Ordered](sort Sorter[T], vec []T) (ours, std time.Duration) {
+	ourVec := slices.Clone(vec)
+	start := time.Now()
+	sort(ourVec)
+	ours = time.Since(start)
+
+	stdVec := slices.Clone(vec)
+	start = time.Now()
+	slices.Sort(stdVec)
+	std = time.Since(start)
+
+	return ours, std
+}
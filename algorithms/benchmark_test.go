@@ -0,0 +1,121 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// CompareToStdlib only returns timings, so each sorter is wrapped to keep
+// hold of the copy it sorted, which has to match what slices.Sort gives
+func TestCompareToStdlib(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	inputs := map[string][]int{
+		"empty":      {},
+		"random":     RandomInts(1000, rng),
+		"sorted":     SortedInts(1000),
+		"reverse":    ReverseSortedInts(1000),
+		"few unique": FewUniqueInts(1000, rng),
+	}
+
+	for name, sort := range Sorters[int]() {
+		for inputName, vec := range inputs {
+			in := slices.Clone(vec)
+
+			var got []int
+			ours, std := CompareToStdlib(func(v []int) { sort(v); got = v }, vec)
+			if ours < 0 || std < 0 {
+				t.Errorf("%s on %s: negative durations %v, %v", name, inputName, ours, std)
+			}
+			if !slices.Equal(vec, in) {
+				t.Fatalf("CompareToStdlib(%s) sorted its input in place", name)
+			}
+			if want := slices.Sorted(slices.Values(in)); !slices.Equal(got, want) {
+				t.Errorf("%s on %s doesn't match slices.Sort", name, inputName)
+			}
+		}
+	}
+}
+
+func BenchmarkCompareToStdlib(b *testing.B) {
+	input := RandomInts(1<<16, rand.New(rand.NewSource(BenchmarkSeed)))
+	benchmarks := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"Sort", Sort[int]},
+		{"slices.Sort", slices.Sort[[]int]},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			vec := make([]int, len(input))
+			for i := 0; i < b.N; i++ {
+				copy(vec, input)
+				bm.sort(vec)
+			}
+		})
+	}
+}
+
+func TestGenerateInput(t *testing.T) {
+	const n = 1000
+	patterns := []struct {
+		pattern InputPattern
+		ok      func(vec []int) bool
+	}{
+		{RandomInput, func(vec []int) bool {
+			return !slices.IsSorted(vec) && slices.Min(vec) >= 0 && slices.Max(vec) < n
+		}},
+		{SortedInput, func(vec []int) bool { return slices.Equal(vec, SortedInts(n)) }},
+		{ReverseSortedInput, func(vec []int) bool { return slices.Equal(vec, Reversed(SortedInts(n))) }},
+		{FewUniqueInput, func(vec []int) bool {
+			return !slices.IsSorted(vec) && slices.Min(vec) >= 0 && slices.Max(vec) < FewUniqueValues
+		}},
+	}
+
+	for _, p := range patterns {
+		t.Run(p.pattern.String(), func(t *testing.T) {
+			vec := GenerateInput(n, p.pattern, rand.New(rand.NewSource(1)))
+			if len(vec) != n || !p.ok(vec) {
+				t.Errorf("GenerateInput(%d, %s) has the wrong shape: %v", n, p.pattern, vec[:10])
+			}
+			// same seed, same input
+			if again := GenerateInput(n, p.pattern, rand.New(rand.NewSource(1))); !slices.Equal(vec, again) {
+				t.Errorf("GenerateInput(%s) isn't deterministic for a seed", p.pattern)
+			}
+		})
+	}
+}
+
+// The counts in a result have to be the ones the instrumented twin of the
+// timed sort gives on the same input, and the twin has to sort the same way
+func TestRunBenchmark(t *testing.T) {
+	const n = 500
+	for _, algo := range Algorithms {
+		for _, pattern := range []InputPattern{RandomInput, SortedInput, ReverseSortedInput, FewUniqueInput} {
+			result := RunBenchmark(algo, n, pattern)
+			if result.Algorithm != algo.Name || result.N != n || result.Pattern != pattern || result.Elapsed < 0 {
+				t.Fatalf("%s on %s: result %+v", algo.Name, pattern, result)
+			}
+			if result.Instrumented != (algo.Instrumented != nil) {
+				t.Fatalf("%s: Instrumented = %v", algo.Name, result.Instrumented)
+			}
+			if algo.Instrumented == nil {
+				continue
+			}
+
+			input := GenerateInput(n, pattern, rand.New(rand.NewSource(BenchmarkSeed)))
+			counted := slices.Clone(input)
+			if stats := algo.Instrumented(counted); stats != result.Stats {
+				t.Errorf("%s on %s: Stats %+v, %sInstrumented gives %+v", algo.Name, pattern, result.Stats, algo.Name, stats)
+			}
+
+			timed := slices.Clone(input)
+			algo.Sort(timed)
+			if !slices.IsSorted(counted) || !slices.Equal(counted, timed) {
+				t.Errorf("%s on %s: the instrumented version sorts differently", algo.Name, pattern)
+			}
+		}
+	}
+}
@@ -0,0 +1,501 @@
+package algorithms
+
+// Stable merge sort in O(n log n) time with O(1) extra memory, a block merge
+// sort after Astrelin's GrailSort. Unlike SymMergeSort it doesn't recurse
+// and doesn't pay O(n log^2 n) moves, but it does a lot more work per
+// element than MergeSort, which trades its O(n) tmp buffer for speed.
+//
+// It starts by pulling about 2*sqrt(n) distinct values out to the front of
+// vec. The first few are keys, which remember which run every block came
+// from, the rest are an internal buffer that merges swap elements in and out
+// of, so nothing is overwritten. Runs are merged by cutting them into
+// sqrt(n) sized blocks, putting the blocks in order by their first element
+// (ties by key, so by which run they came from) with a selection sort, and
+// then merging every block into the one before it through the buffer. At
+// the end the keys and buffer get insertion sorted and merged back in, they
+// were the first of their values so that keeps it stable.
+//
+// Without enough distinct values for a buffer the keys double as one, or the
+// blocks get merged with rotations instead. With fewer than 4 distinct
+// values everything is merged with rotations, which is linear per merge when
+// there are only that few values.
+func BlockMergeSort[T Ordered](vec []T) {
+	n := len(vec)
+	if n < 16 {
+		InsertionSort(vec)
+		return
+	}
+
+	blockLen := 1
+	for blockLen*blockLen < n {
+		blockLen *= 2
+	}
+	keyLen := (n-1)/blockLen + 1
+	found := blockFindKeys(vec, keyLen+blockLen)
+
+	hasBuf := true
+	if found < keyLen+blockLen {
+		if found < 4 {
+			blockLazySort(vec)
+			return
+		}
+		keyLen = blockLen
+		for keyLen > found {
+			keyLen /= 2
+		}
+		hasBuf = false
+		blockLen = 0
+	}
+
+	// keys in vec[:keyLen], the buffer in vec[keyLen:start] and everything
+	// else to sort in vec[start:]
+	start := blockLen + keyLen
+	runLen := blockLen
+	if !hasBuf {
+		runLen = keyLen
+	}
+	blockBuildRuns(vec, start, n-start, runLen)
+
+	// runs of 2*runLen are sorted now
+	for {
+		runLen *= 2
+		if n-start <= runLen {
+			break
+		}
+
+		lb, buffered := blockLen, hasBuf
+		if !hasBuf {
+			if keyLen > 4 && keyLen/8*keyLen >= runLen {
+				// half the keys are enough, the other half can be a buffer
+				lb = keyLen / 2
+				buffered = true
+			} else {
+				// as many blocks as there are keys for
+				nk := 1
+				s := int64(runLen) * int64(found) / 2
+				for nk < keyLen && s != 0 {
+					nk *= 2
+					s /= 8
+				}
+				lb = 2 * runLen / nk
+			}
+		}
+		blockCombine(vec, start, n-start, runLen, lb, buffered)
+	}
+
+	InsertionSort(vec[:start])
+	blockMergeInPlace(vec, 0, start, n-start)
+}
+
+// rotate, but either side may be empty
+func blockRotate[T any](vec []T, a, len1, len2 int) {
+	if len1 > 0 && len2 > 0 {
+		rotate(vec, a, a+len1, a+len1+len2)
+	}
+}
+
+// Number of elements in vec[a:a+n] less than key
+func blockSearchLeft[T Ordered](vec []T, a, n int, key T) int {
+	lo, hi := 0, n
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if vec[a+mid] < key {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// Number of elements in vec[a:a+n] less than or equal to key
+func blockSearchRight[T Ordered](vec []T, a, n int, key T) int {
+	lo, hi := 0, n
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if !(key < vec[a+mid]) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// Moves the first occurrence of up to want distinct values to the front of
+// vec, sorted, and returns how many it found. The keys found so far are
+// kept together as a block that gets rotated along as the scan goes, so the
+// other elements stay in order
+func blockFindKeys[T Ordered](vec []T, want int) int {
+	// the keys are vec[h0:h0+h]
+	h0, h := 0, 1
+	for u := 1; u < len(vec) && h < want; u++ {
+		r := blockSearchLeft(vec, h0, h, vec[u])
+		if r == h || vec[u] < vec[h0+r] {
+			blockRotate(vec, h0, h, u-(h0+h))
+			h0 = u - h
+			blockRotate(vec, h0+r, h-r, 1)
+			h++
+		}
+	}
+	blockRotate(vec, 0, h0, h)
+	return h
+}
+
+// Merges the sorted runs vec[a:a+len1] and vec[a+len1:a+len1+len2] with
+// rotations. Every rotation moves a whole group of equal elements, so it's
+// O(len1+len2) when there are only a few distinct values and
+// O(min(len1, len2)^2 + len1 + len2) at worst
+func blockMergeInPlace[T Ordered](vec []T, a, len1, len2 int) {
+	if len1 < len2 {
+		for len1 > 0 {
+			// everything on the right less than the first on the left goes
+			// in front of it
+			h := blockSearchLeft(vec, a+len1, len2, vec[a])
+			if h != 0 {
+				blockRotate(vec, a, len1, h)
+				a += h
+				len2 -= h
+			}
+			if len2 == 0 {
+				break
+			}
+			for {
+				a++
+				len1--
+				if len1 == 0 || vec[a+len1] < vec[a] {
+					break
+				}
+			}
+		}
+		return
+	}
+
+	for len2 > 0 {
+		// everything on the left greater than the last on the right goes
+		// after it
+		h := blockSearchRight(vec, a, len1, vec[a+len1+len2-1])
+		if h != len1 {
+			blockRotate(vec, a+h, len1-h, len2)
+			len1 = h
+		}
+		if len1 == 0 {
+			break
+		}
+		for {
+			len2--
+			if len2 == 0 || vec[a+len1+len2-1] < vec[a+len1-1] {
+				break
+			}
+		}
+	}
+}
+
+// Merges vec[a:a+len1] and vec[a+len1:a+len1+len2] into the buffer at
+// vec[buf:], which starts before a. Merged elements are swapped with the
+// buffer, so the buffer ends up right after the merged run
+func blockMergeLeft[T Ordered](vec []T, a, len1, len2, buf int) {
+	p0, p1 := a, a+len1
+	end1, end := a+len1, a+len1+len2
+	for p1 < end {
+		if p0 == end1 || vec[p1] < vec[p0] {
+			vec[buf], vec[p1] = vec[p1], vec[buf]
+			p1++
+		} else {
+			vec[buf], vec[p0] = vec[p0], vec[buf]
+			p0++
+		}
+		buf++
+	}
+	if buf != p0 {
+		swapRange(vec, buf, p0, end1-p0)
+	}
+}
+
+// blockMergeLeft from the other end, with the bufLen long buffer right after
+// vec[a:a+len1+len2]. The buffer ends up at vec[a:a+bufLen]
+func blockMergeRight[T Ordered](vec []T, a, len1, len2, bufLen int) {
+	dst := a + len1 + len2 + bufLen - 1
+	p1, p2 := a+len1-1, a+len1+len2-1
+	for p1 >= a {
+		if p2 < a+len1 || vec[p2] < vec[p1] {
+			vec[dst], vec[p1] = vec[p1], vec[dst]
+			p1--
+		} else {
+			vec[dst], vec[p2] = vec[p2], vec[dst]
+			p2--
+		}
+		dst--
+	}
+	if p2 != dst {
+		for p2 >= a+len1 {
+			vec[dst], vec[p2] = vec[p2], vec[dst]
+			dst--
+			p2--
+		}
+	}
+}
+
+// Whether x, from the run left over by the last block merge, goes before y
+// from the next block. Equal elements go to whichever came from the left run
+func blockBefore[T Ordered](x, y T, restLeft bool) bool {
+	if restLeft {
+		return !(y < x)
+	}
+	return x < y
+}
+
+// Merges the rest of the previous block, vec[a:a+restLen], with the next
+// block of blockLen through the buffer just before a, until one of them runs
+// out. Whatever is left of the other one is moved to the end and is the new
+// rest, its length and which run it came from are returned
+func blockSmartMerge[T Ordered](vec []T, a, restLen int, restLeft bool, blockLen int) (int, bool) {
+	p0, p1, p2 := a-blockLen, a, a+restLen
+	q1, q2 := p2, p2+blockLen
+	for p1 < q1 && p2 < q2 {
+		if blockBefore(vec[p1], vec[p2], restLeft) {
+			vec[p0], vec[p1] = vec[p1], vec[p0]
+			p1++
+		} else {
+			vec[p0], vec[p2] = vec[p2], vec[p0]
+			p2++
+		}
+		p0++
+	}
+
+	if p1 < q1 {
+		restLen = q1 - p1
+		for p1 < q1 {
+			q1--
+			q2--
+			vec[q1], vec[q2] = vec[q2], vec[q1]
+		}
+		return restLen, restLeft
+	}
+	return q2 - p2, !restLeft
+}
+
+// blockSmartMerge with rotations instead of a buffer
+func blockSmartMergeInPlace[T Ordered](vec []T, a, restLen int, restLeft bool, blockLen int) (int, bool) {
+	if blockLen == 0 {
+		return restLen, restLeft
+	}
+
+	len1, len2 := restLen, blockLen
+	if len1 > 0 && !blockBefore(vec[a+len1-1], vec[a+len1], restLeft) {
+		for len1 > 0 {
+			var h int
+			if restLeft {
+				h = blockSearchLeft(vec, a+len1, len2, vec[a])
+			} else {
+				h = blockSearchRight(vec, a+len1, len2, vec[a])
+			}
+			if h != 0 {
+				blockRotate(vec, a, len1, h)
+				a += h
+				len2 -= h
+			}
+			if len2 == 0 {
+				return len1, restLeft
+			}
+			for {
+				a++
+				len1--
+				if len1 == 0 || !blockBefore(vec[a], vec[a+len1], restLeft) {
+					break
+				}
+			}
+		}
+	}
+	return len2, !restLeft
+}
+
+// Sorts vec[a:a+n] into runs of 2*bufLen, using the bufLen elements before
+// a as the buffer. The runs end up in vec[a-bufLen:] and the buffer right
+// after them, then get merged back towards the end so that the buffer is
+// before a again and the runs are where they started
+func blockBuildRuns[T Ordered](vec []T, a, n, bufLen int) {
+	// pairs, moved 2 to the left
+	for m := 1; m < n; m += 2 {
+		u := 0
+		if vec[a+m] < vec[a+m-1] {
+			u = 1
+		}
+		vec[a+m-3], vec[a+m-1+u] = vec[a+m-1+u], vec[a+m-3]
+		vec[a+m-2], vec[a+m-u] = vec[a+m-u], vec[a+m-2]
+	}
+	if n%2 == 1 {
+		vec[a+n-1], vec[a+n-3] = vec[a+n-3], vec[a+n-1]
+	}
+	a -= 2
+
+	for h := 2; h < bufLen; h *= 2 {
+		p := 0
+		for ; p <= n-2*h; p += 2 * h {
+			blockMergeLeft(vec, a+p, h, h, a+p-h)
+		}
+		if rest := n - p; rest > h {
+			blockMergeLeft(vec, a+p, h, rest-h, a+p-h)
+		} else {
+			blockRotate(vec, a+p-h, h, rest)
+		}
+		a -= h
+	}
+
+	restRun := n % (2 * bufLen)
+	p := n - restRun
+	if restRun <= bufLen {
+		blockRotate(vec, a+p, restRun, bufLen)
+	} else {
+		blockMergeRight(vec, a+p, bufLen, restRun-bufLen, bufLen)
+	}
+	for p > 0 {
+		p -= 2 * bufLen
+		blockMergeRight(vec, a+p, bufLen, bufLen, bufLen)
+	}
+}
+
+// Merges the pairs of runLen long runs in vec[a:a+n], blockLen at a time.
+// The keys are at the front of vec, with buffered the blockLen elements
+// before a are the buffer
+func blockCombine[T Ordered](vec []T, a, n, runLen, blockLen int, buffered bool) {
+	pairs := n / (2 * runLen)
+	restRun := n % (2 * runLen)
+	// a run without anything to merge with is already where it belongs
+	if restRun <= runLen {
+		n -= restRun
+		restRun = 0
+	}
+
+	for b := 0; b <= pairs; b++ {
+		if b == pairs && restRun == 0 {
+			break
+		}
+
+		start := a + b*2*runLen
+		blocks := 2 * runLen / blockLen
+		keys := blocks
+		if b == pairs {
+			blocks = restRun / blockLen
+			keys = blocks + 1
+		}
+		InsertionSort(vec[:keys])
+
+		// vec[mid] is the key of the first block of the right run
+		mid := runLen / blockLen
+		for u := 1; u < blocks; u++ {
+			p := u - 1
+			for v := u; v < blocks; v++ {
+				x, y := vec[start+p*blockLen], vec[start+v*blockLen]
+				if y < x || (!(x < y) && vec[v] < vec[p]) {
+					p = v
+				}
+			}
+			if p != u-1 {
+				swapRange(vec, start+(u-1)*blockLen, start+p*blockLen, blockLen)
+				vec[u-1], vec[p] = vec[p], vec[u-1]
+				if mid == u-1 || mid == p {
+					mid ^= (u - 1) ^ p
+				}
+			}
+		}
+
+		// the last pair can end in a partial block, and the blocks of the
+		// left run greater than it go after it
+		after, tail := 0, 0
+		if b == pairs {
+			tail = restRun % blockLen
+		}
+		if tail != 0 {
+			for after < blocks && vec[start+blocks*blockLen] < vec[start+(blocks-after-1)*blockLen] {
+				after++
+			}
+		}
+		blockMergeBlocks(vec, mid, start, blocks-after, blockLen, buffered, after, tail)
+	}
+
+	if buffered {
+		// move everything back over the buffer
+		for n--; n >= 0; n-- {
+			vec[a+n], vec[a+n-blockLen] = vec[a+n-blockLen], vec[a+n]
+		}
+	}
+}
+
+// Merges the blocks in vec[a:], which are in order by their first element,
+// every one into the ones before it. vec[i] is the key of block i, vec[mid]
+// the first key of the right run. after more full blocks and tail elements
+// follow and get merged in last
+func blockMergeBlocks[T Ordered](vec []T, mid, a, blocks, blockLen int, buffered bool, after, tail int) {
+	if blocks == 0 {
+		l := after * blockLen
+		if buffered {
+			blockMergeLeft(vec, a, l, tail, a-blockLen)
+		} else {
+			blockMergeInPlace(vec, a, l, tail)
+		}
+		return
+	}
+
+	restLen := blockLen
+	restLeft := vec[0] < vec[mid]
+	next := blockLen
+	for i := 1; i < blocks; i, next = i+1, next+blockLen {
+		rest := next - restLen
+		nextLeft := vec[i] < vec[mid]
+		if nextLeft == restLeft {
+			// nothing to merge, the rest is done
+			if buffered {
+				swapRange(vec, a+rest-blockLen, a+rest, restLen)
+			}
+			restLen = blockLen
+		} else if buffered {
+			restLen, restLeft = blockSmartMerge(vec, a+rest, restLen, restLeft, blockLen)
+		} else {
+			restLen, restLeft = blockSmartMergeInPlace(vec, a+rest, restLen, restLeft, blockLen)
+		}
+	}
+
+	rest := next - restLen
+	if tail == 0 {
+		if buffered {
+			swapRange(vec, a+rest, a+rest-blockLen, restLen)
+		}
+		return
+	}
+
+	if !restLeft {
+		if buffered {
+			swapRange(vec, a+rest-blockLen, a+rest, restLen)
+		}
+		rest = next
+		restLen = blockLen * after
+	} else {
+		restLen += blockLen * after
+	}
+	if buffered {
+		blockMergeLeft(vec, a+rest, restLen, tail, a+rest-blockLen)
+	} else {
+		blockMergeInPlace(vec, a+rest, restLen, tail)
+	}
+}
+
+// Bottom up merge sort that merges with rotations only, for when there
+// aren't even 4 distinct values to work with
+func blockLazySort[T Ordered](vec []T) {
+	n := len(vec)
+	for m := 1; m < n; m += 2 {
+		if vec[m] < vec[m-1] {
+			vec[m-1], vec[m] = vec[m], vec[m-1]
+		}
+	}
+	for h := 2; h < n; h *= 2 {
+		p := 0
+		for ; p <= n-2*h; p += 2 * h {
+			blockMergeInPlace(vec, p, h, h)
+		}
+		if rest := n - p; rest > h {
+			blockMergeInPlace(vec, p, h, rest-h)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Every sequence of up to 8 values out of -1, -0, +0, 1, split at every
+// point, both halves sorted and merged with rotations
+func TestBlockMergeInPlaceStable(t *testing.T) {
+	alphabet := []float64{-1, math.Copysign(0, -1), 0, 1}
+
+	for n := 1; n <= 8; n++ {
+		seq := make([]int, n)
+		for {
+			vec := make([]float64, n)
+			for i, c := range seq {
+				vec[i] = alphabet[c]
+			}
+
+			for m := 0; m <= n; m++ {
+				merged := slices.Clone(vec)
+				InsertionSort(merged[:m])
+				InsertionSort(merged[m:])
+				want := zeroSigns(merged)
+				blockMergeInPlace(merged, 0, m, n-m)
+				if !slices.IsSorted(merged) || !slices.Equal(zeroSigns(merged), want) {
+					t.Fatalf("blockMergeInPlace(%v, %d) = %v, not stable", vec, m, merged)
+				}
+			}
+
+			// next sequence, counting in base len(alphabet)
+			i := 0
+			for i < n && seq[i] == len(alphabet)-1 {
+				seq[i] = 0
+				i++
+			}
+			if i == n {
+				break
+			}
+			seq[i]++
+		}
+	}
+}
+
+// 16 elements is the smallest BlockMergeSort doesn't insertion sort, and 8
+// distinct values out of 16 is just enough for keys and a full buffer. So
+// every permutation of 0..7 followed by the same permutation reversed, with
+// each value in turn standing in as -0 in the first half and +0 in the second
+func TestBlockMergeSortAllPermutations(t *testing.T) {
+	const n = 8
+	negZero := math.Copysign(0, -1)
+
+	vec := make([]float64, 2*n)
+	for _, perm := range permutations(n) {
+		for zero := 0; zero < n; zero++ {
+			for i, val := range perm {
+				vec[i] = float64(val - zero)
+				vec[2*n-1-i] = float64(val - zero)
+			}
+			vec[slices.Index(perm, zero)] = negZero
+
+			BlockMergeSort(vec)
+			for i := range vec {
+				if vec[i] != float64(i/2-zero) {
+					t.Fatalf("BlockMergeSort(%v, zero=%d) = %v", perm, zero, vec)
+				}
+			}
+			if i := 2 * zero; !math.Signbit(vec[i]) || math.Signbit(vec[i+1]) {
+				t.Fatalf("BlockMergeSort(%v, zero=%d) isn't stable", perm, zero)
+			}
+		}
+	}
+}
+
+// From a couple of distinct values to all distinct, so that every way of
+// getting by with the keys that could be found gets used: rotations only
+// below 4 distinct values, keys without a buffer, keys that are half buffer
+// and keys plus a full buffer
+func TestBlockMergeSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	negZero := math.Copysign(0, -1)
+
+	sizes := []int{0, 1, 15, 16, 17, 100, 255, 256, 257, 1000, 4099, 1 << 16}
+	for n := 18; n < 600; n += 7 {
+		sizes = append(sizes, n)
+	}
+
+	for _, n := range sizes {
+		for _, distinct := range []int{1, 2, 3, 4, 5, 8, 30, 100, n + 1} {
+			vec := make([]float64, n)
+			for i := range vec {
+				vec[i] = float64(rng.Intn(distinct) - distinct/2)
+				if vec[i] == 0 && rng.Intn(2) == 0 {
+					vec[i] = negZero
+				}
+			}
+			want := zeroSigns(vec)
+			sorted := slices.Sorted(slices.Values(vec))
+
+			BlockMergeSort(vec)
+			if !slices.Equal(vec, sorted) {
+				t.Fatalf("n=%d, %d distinct: not sorted", n, distinct)
+			}
+			if !slices.Equal(zeroSigns(vec), want) {
+				t.Fatalf("n=%d, %d distinct: not stable", n, distinct)
+			}
+		}
+	}
+}
+
+func TestBlockMergeSortDoesNotAllocate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	inputs := map[string][]int{
+		"random":     RandomInts(10_000, rng),
+		"few unique": FewUniqueInts(10_000, rng),
+		"reversed":   ReverseSortedInts(10_000),
+		"two values": make([]int, 10_000),
+	}
+	for i := range inputs["two values"] {
+		inputs["two values"][i] = rng.Intn(2)
+	}
+
+	for name, in := range inputs {
+		vec := make([]int, len(in))
+		allocs := testing.AllocsPerRun(10, func() {
+			copy(vec, in)
+			BlockMergeSort(vec)
+		})
+		if allocs != 0 {
+			t.Errorf("%s: %v allocations per sort, want 0", name, allocs)
+		}
+	}
+}
+
+func BenchmarkBlockMergeSort(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"BlockMergeSort", BlockMergeSort[int]},
+		{"SymMergeSort", SymMergeSort[int]},
+		{"MergeSort", MergeSort[int]},
+	}
+
+	input := RandomInts(1<<16, rand.New(rand.NewSource(BenchmarkSeed)))
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			vec := make([]int, len(input))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(vec, input)
+				bm.sort(vec)
+			}
+		})
+	}
+}
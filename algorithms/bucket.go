@@ -0,0 +1,193 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// Bucket sort for any type. mapTo01 should place every element somewhere in
+// [0, 1) in a way that agrees with cmp, e.g. (x - min) / (max - min + 1) for
+// ints with a known range. Values outside of [0, 1) are clamped into the first
+// or last bucket.
+//
+// A poor mapping only hurts performance, never correctness: buckets are sorted
+// with cmp and a final insertion sort pass over the whole slice fixes anything
+// the mapping put in the wrong bucket. With a good mapping that last pass is
+// linear, with a terrible one it degrades to plain insertion sort.
+func BucketSortFunc[T any](vec []T, mapTo01 func(T) float64, cmp func(a, b T) int) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	numBuckets := len(vec)
+	buckets := make([][]T, numBuckets)
+
+	for _, val := range vec {
+		index := bucketIndex(mapTo01(val), numBuckets)
+		buckets[index] = append(buckets[index], val)
+	}
+
+	k := 0
+	for i := range buckets {
+		InsertionSortCmp(buckets[i], cmp)
+		for _, val := range buckets[i] {
+			vec[k] = val
+			k++
+		}
+	}
+
+	InsertionSortCmp(vec, cmp)
+}
+
+// Which of numBuckets buckets pos goes into, pos should be in [0, 1).
+// Anything below 0 goes into the first bucket and anything from 1 up,
+// +Inf included, into the last one
+func bucketIndex(pos float64, numBuckets int) int {
+	// written this way so NaN ends up in the first bucket
+	if !(pos > 0) {
+		return 0
+	}
+	// clamp before converting, int(+Inf) isn't defined
+	if pos >= 1 {
+		return numBuckets - 1
+	}
+	return min(int(pos*float64(numBuckets)), numBuckets-1)
+}
+
+// Moves the NaNs to the end of vec, which is where CompareFloat puts them,
+// and returns the rest. No bucket can be computed for a NaN
+func splitNaNs(vec []float64) []float64 {
+	return vec[:StablePartition(vec, math.IsNaN)]
+}
+
+// Min and max of the finite values in vec, false if there are none. The
+// infinities would make every finite value land in the same bucket
+func finiteMinMax(vec []float64) (lo, hi float64, ok bool) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, val := range vec {
+		if !math.IsInf(val, 0) {
+			lo, hi = min(lo, val), max(hi, val)
+			ok = true
+		}
+	}
+	return lo, hi, ok
+}
+
+// Same idea as BucketSort, but the buckets are sorted by up to GOMAXPROCS
+// goroutines at once. Buckets don't share any memory so there is nothing to
+// lock, the distribution and the final copy stay sequential. The buckets
+// split the range of the finite values, -Inf and +Inf go into the first and
+// last bucket and NaNs are moved to the end, like CompareFloat orders them.
+func ParallelBucketSort(vec []float64) {
+	vec = splitNaNs(vec)
+	if len(vec) <= 1 {
+		return
+	}
+
+	lo, hi, ok := finiteMinMax(vec)
+	if !ok || lo == hi {
+		// nothing to split up, at most one finite value and infinities
+		IntroSort(vec)
+		return
+	}
+
+	// sqrt(n) buckets, so there is plenty of work to spread out no matter
+	// what the range of the values is
+	numBuckets := int(math.Sqrt(float64(len(vec)))) + 1
+	buckets := make([][]float64, numBuckets)
+
+	for _, val := range vec {
+		index := bucketIndex((val-lo)/(hi-lo), numBuckets)
+		buckets[index] = append(buckets[index], val)
+	}
+
+	work := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				IntroSort(buckets[i])
+			}
+		}()
+	}
+
+	for i := range buckets {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	k := 0
+	for i := range buckets {
+		k += copy(vec[k:], buckets[i])
+	}
+}
+
+// Bucket sort with bucket edges picked by the caller, e.g. the edges of a
+// histogram of the data. edges must be sorted in ascending order, it panics
+// otherwise. There are len(edges)+1 buckets: bucket i holds the values in
+// [edges[i-1], edges[i]), the first one everything below edges[0] and the
+// last one everything from the last edge up. So edges don't have to cover
+// the range of the data, values outside of it just end up in the outer
+// buckets and are sorted there like anything else.
+//
+// Finding the bucket is a binary search over edges, O(log len(edges)) per
+// value. NaNs in vec are moved to the end like ParallelBucketSort does, a
+// NaN edge panics
+func BucketSortBounds(vec []float64, edges []float64) {
+	for i := range edges {
+		if math.IsNaN(edges[i]) {
+			panic(fmt.Sprintf("algorithms: BucketSortBounds edge %d is NaN", i))
+		}
+		if i > 0 && edges[i] < edges[i-1] {
+			panic(fmt.Sprintf("algorithms: BucketSortBounds edges not sorted at index %d", i))
+		}
+	}
+
+	vec = splitNaNs(vec)
+	if len(vec) <= 1 {
+		return
+	}
+
+	buckets := make([][]float64, len(edges)+1)
+	for _, val := range vec {
+		index := UpperBound(edges, val)
+		buckets[index] = append(buckets[index], val)
+	}
+
+	k := 0
+	for i := range buckets {
+		IntroSort(buckets[i])
+		k += copy(vec[k:], buckets[i])
+	}
+}
+
+// How BucketSort spread the values over its buckets
+type BucketStats struct {
+	// Counts[i] is how many values went into bucket i
+	Counts []int
+	// Size of the fullest bucket. Close to len(vec) means nearly everything
+	// went into one bucket and the sort was mostly that bucket's QuickSort
+	MaxBucket int
+	// Buckets that didn't get anything
+	Empty int
+}
+
+// Sorts vec exactly like BucketSort and reports how full the buckets were.
+// BucketSort assumes the values are spread evenly between the min and the
+// max, an uneven MaxBucket shows when they aren't
+func BucketSortStats(vec []float64) BucketStats {
+	stats := BucketStats{Counts: bucketSort(vec)}
+	for _, c := range stats.Counts {
+		stats.MaxBucket = max(stats.MaxBucket, c)
+		if c == 0 {
+			stats.Empty++
+		}
+	}
+	return stats
+}
@@ -0,0 +1,290 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// Equal in the order CompareFloat defines, except -0 and +0 count as equal
+// since the bucket sorts leave those mixed up
+func sameFloats(a, b []float64) bool {
+	return slices.EqualFunc(a, b, func(x, y float64) bool {
+		return x == y || math.IsNaN(x) && math.IsNaN(y)
+	})
+}
+
+func floatInputs() map[string][]float64 {
+	rng := rand.New(rand.NewSource(1))
+	inf, nan := math.Inf(1), math.NaN()
+
+	uniform := make([]float64, 10_000)
+	for i := range uniform {
+		uniform[i] = rng.Float64()*200 - 100
+	}
+	// every bucket that gets anything is all duplicates, which is quadratic
+	// for QuickSort
+	fewUnique := make([]float64, 200_000)
+	for i := range fewUnique {
+		fewUnique[i] = []float64{-7.5, 0, 42}[rng.Intn(3)]
+	}
+	withSpecials := slices.Clone(uniform)
+	for i := 0; i < 50; i++ {
+		withSpecials[rng.Intn(len(withSpecials))] = []float64{inf, -inf, nan}[i%3]
+	}
+
+	return map[string][]float64{
+		"empty":        {},
+		"single":       {1},
+		"single NaN":   {nan},
+		"uniform":      uniform,
+		"all equal":    slices.Repeat([]float64{3}, 1000),
+		"few unique":   fewUnique,
+		"+Inf":         {1, inf, 2, 0.5},
+		"-Inf":         {1, -inf, 2, 0.5},
+		"both Infs":    {inf, 1, -inf, 2, -inf, inf},
+		"only Infs":    {inf, -inf, inf, -inf},
+		"NaN":          {2, nan, 1, nan, 3},
+		"only NaN":     {nan, nan, nan},
+		"NaN and Infs": {nan, inf, -inf, nan, 0},
+		"one finite":   {inf, 5, -inf, nan, 5},
+		"huge range":   {math.MaxFloat64, -math.MaxFloat64, 0, 1, -1},
+		"specials":     withSpecials,
+	}
+}
+
+func TestParallelBucketSort(t *testing.T) {
+	for name, in := range floatInputs() {
+		t.Run(name, func(t *testing.T) {
+			vec := slices.Clone(in)
+			ParallelBucketSort(vec)
+
+			want := slices.Clone(in)
+			slices.SortFunc(want, CompareFloat)
+			if !sameFloats(vec, want) {
+				t.Errorf("ParallelBucketSort(%v) = %v, want %v", in, vec, want)
+			}
+		})
+	}
+}
+
+// Several sorts at once, run with -race. Each one spreads its buckets over
+// GOMAXPROCS goroutines, which mustn't touch each other's buckets
+func TestParallelBucketSortConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		vec := make([]float64, 100_000)
+		rng := rand.New(rand.NewSource(int64(g)))
+		for i := range vec {
+			vec[i] = rng.NormFloat64()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ParallelBucketSort(vec)
+			if !slices.IsSorted(vec) {
+				t.Errorf("goroutine %d: not sorted", g)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBucketSortBounds(t *testing.T) {
+	edgeSets := map[string][]float64{
+		"none":          nil,
+		"inside":        {-50, 0, 50},
+		"outside":       {1000, 2000},
+		"duplicates":    {0, 0, 1, 1},
+		"infinite":      {math.Inf(-1), 0, math.Inf(1)},
+		"one each side": {-1e300, 1e300},
+	}
+
+	for name, in := range floatInputs() {
+		for edgesName, edges := range edgeSets {
+			t.Run(name+"/"+edgesName, func(t *testing.T) {
+				vec := slices.Clone(in)
+				BucketSortBounds(vec, edges)
+
+				want := slices.Clone(in)
+				slices.SortFunc(want, CompareFloat)
+				if !sameFloats(vec, want) {
+					t.Errorf("BucketSortBounds(%v, %v) = %v, want %v", in, edges, vec, want)
+				}
+			})
+		}
+	}
+}
+
+// Exponentially distributed values with edges picked like a log scale
+// histogram would, plus values sitting exactly on the edges and far outside
+// of them
+func TestBucketSortBoundsSkewed(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	edges := []float64{0.01, 0.1, 0.5, 1, 2, 5}
+	exp := make([]float64, 100_000)
+	for i := range exp {
+		exp[i] = rng.ExpFloat64()
+	}
+
+	tests := []struct {
+		name string
+		vec  []float64
+	}{
+		{"exponential", exp},
+		{"on the edges", []float64{5, 1, 0.01, 2, 0.5, 0.1, 1, 5}},
+		{"outside", []float64{1e9, -1e9, 3, -0.5, 1e-9, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			BucketSortBounds(vec, edges)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(tt.vec))) {
+				t.Errorf("BucketSortBounds didn't sort %s input", tt.name)
+			}
+		})
+	}
+}
+
+func TestBucketSortBoundsPanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		edges []float64
+	}{
+		{"unsorted", []float64{1, 0}},
+		{"NaN", []float64{0, math.NaN(), 1}},
+		{"only NaN", []float64{math.NaN()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("BucketSortBounds(%v) didn't panic", tt.edges)
+				}
+			}()
+			BucketSortBounds([]float64{1, 2}, tt.edges)
+		})
+	}
+}
+
+// Infinities used to turn into int(+Inf) and index out of the buckets
+func TestBucketSortFuncClamps(t *testing.T) {
+	vec := []float64{0.5, math.Inf(1), -3, 0.25, math.Inf(-1), 7, 0}
+	BucketSortFunc(vec, func(v float64) float64 { return v }, CompareFloat)
+
+	want := []float64{math.Inf(-1), -3, 0, 0.25, 0.5, 7, math.Inf(1)}
+	if !slices.Equal(vec, want) {
+		t.Errorf("BucketSortFunc = %v, want %v", vec, want)
+	}
+}
+
+func BenchmarkParallelBucketSort(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		sort func([]float64)
+	}{
+		{"ParallelBucketSort", ParallelBucketSort},
+		{"BucketSort", BucketSort},
+		{"slices.Sort", slices.Sort[[]float64]},
+	}
+	sizes := []struct {
+		name string
+		n    int
+	}{
+		{"100K", 100_000},
+		{"10M", 10_000_000},
+	}
+
+	for _, size := range sizes {
+		var input []float64
+		for _, bm := range benchmarks {
+			b.Run(bm.name+"/"+size.name, func(b *testing.B) {
+				if input == nil {
+					rng := rand.New(rand.NewSource(BenchmarkSeed))
+					input = make([]float64, size.n)
+					for i := range input {
+						input[i] = rng.Float64()
+					}
+				}
+				vec := make([]float64, len(input))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					copy(vec, input)
+					bm.sort(vec)
+				}
+			})
+		}
+	}
+}
+
+// Uniform values spread over the buckets, a skewed slice with a few outliers
+// stretching the range piles nearly everything into the first one
+func TestBucketSortStats(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 10_000
+
+	uniform := make([]float64, n)
+	for i := range uniform {
+		uniform[i] = rng.Float64() * 1000
+	}
+	skewed := make([]float64, n)
+	for i := range skewed {
+		skewed[i] = rng.Float64()
+	}
+	for i := 0; i < 10; i++ {
+		skewed[rng.Intn(n)] = 1000 - float64(i)
+	}
+
+	tests := []struct {
+		name     string
+		vec      []float64
+		balanced bool
+	}{
+		{"uniform", uniform, true},
+		{"skewed", skewed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			stats := BucketSortStats(vec)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(tt.vec))) {
+				t.Fatalf("BucketSortStats didn't sort")
+			}
+
+			total, biggest, empty := 0, 0, 0
+			for _, c := range stats.Counts {
+				total += c
+				biggest = max(biggest, c)
+				if c == 0 {
+					empty++
+				}
+			}
+			if total != n || biggest != stats.MaxBucket || empty != stats.Empty {
+				t.Fatalf("stats %+v don't add up: %d values, max %d, %d empty", stats, total, biggest, empty)
+			}
+			if len(stats.Counts) < 2 {
+				t.Fatalf("only %d buckets", len(stats.Counts))
+			}
+
+			// twice the average is plenty for uniform data
+			balanced := stats.MaxBucket <= 2*n/len(stats.Counts)
+			if balanced != tt.balanced {
+				t.Errorf("MaxBucket %d of %d values in %d buckets, want balanced %v",
+					stats.MaxBucket, n, len(stats.Counts), tt.balanced)
+			}
+			if !tt.balanced && stats.Empty < len(stats.Counts)/2 {
+				t.Errorf("only %d of %d buckets empty on skewed input", stats.Empty, len(stats.Counts))
+			}
+		})
+	}
+
+	if stats := BucketSortStats([]float64{3, 3, 3}); stats.MaxBucket != 3 || stats.Empty != 0 {
+		t.Errorf("BucketSortStats on equal values = %+v", stats)
+	}
+}
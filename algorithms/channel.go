@@ -0,0 +1,81 @@
+package algorithms
+
+import "context"
+
+// Drains in until it is closed and returns everything sorted. If ctx is
+// cancelled first, it stops reading and returns ctx.Err()
+func SortChannel[T Ordered](ctx context.Context, in <-chan T) ([]T, error) {
+	var vec []T
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case val, ok := <-in:
+			if !ok {
+				IntroSort(vec)
+				return vec, nil
+			}
+			vec = append(vec, val)
+		}
+	}
+}
+
+// Like SortChannel but only keeps the k smallest elements, in ascending
+// order. Uses a max-heap of size k, so it is O(n log k) time and O(k) memory
+func TopKChannel[T Ordered](ctx context.Context, in <-chan T, k int) ([]T, error) {
+	if k < 0 {
+		k = 0
+	}
+
+	heap := make([]T, 0, k)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case val, ok := <-in:
+			if !ok {
+				HeapSort(heap)
+				return heap, nil
+			}
+
+			if len(heap) < k {
+				heap = append(heap, val)
+				if len(heap) == k {
+					buildHeap(heap)
+				}
+			} else if k > 0 && val < heap[0] {
+				// Root is the largest of the k smallest so far, so it goes
+				heap[0] = val
+				heapify(heap, 0, k)
+			}
+		}
+	}
+}
+
+// Merges channels that each deliver ascending values into one ascending
+// channel, using the same min-heap of heads as ExternalSort. The output is
+// closed once every input is closed and drained. Channels can close at any
+// time, but every input has to be closed eventually and the output has to be
+// read until it is closed, otherwise the merging goroutine never exits
+func MergeKChannels[T Ordered](ins ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		next := func(src int) (T, bool, error) {
+			val, ok := <-ins[src]
+			return val, ok, nil
+		}
+
+		emit := func(val T) error {
+			out <- val
+			return nil
+		}
+
+		// neither next nor emit can fail
+		_ = kWayMerge(len(ins), next, emit)
+	}()
+
+	return out
+}
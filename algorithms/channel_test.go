@@ -0,0 +1,137 @@
+package algorithms
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Starts one goroutine per part, each sending its part to the returned
+// channel, which is closed once all of them are done
+func produce(parts ...[]int) <-chan int {
+	ch := make(chan int)
+	var wg sync.WaitGroup
+	for _, part := range parts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, val := range part {
+				ch <- val
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+	return ch
+}
+
+func splitInts(n, parts int, rng *rand.Rand) (all []int, split [][]int) {
+	all = RandomInts(n, rng)
+	for i := 0; i < parts; i++ {
+		split = append(split, all[i*n/parts:(i+1)*n/parts])
+	}
+	return all, split
+}
+
+func TestSortChannel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, tt := range []struct{ n, producers int }{{0, 1}, {1, 1}, {1000, 1}, {1000, 8}, {10_000, 3}} {
+		all, parts := splitInts(tt.n, tt.producers, rng)
+		got, err := SortChannel(context.Background(), produce(parts...))
+		if err != nil {
+			t.Fatalf("SortChannel = %v", err)
+		}
+		if want := slices.Sorted(slices.Values(all)); !slices.Equal(got, want) {
+			t.Errorf("n=%d from %d producers: got %v", tt.n, tt.producers, got)
+		}
+	}
+}
+
+func TestTopKChannel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	all, parts := splitInts(2000, 4, rng)
+	sorted := slices.Sorted(slices.Values(all))
+
+	for _, k := range []int{-1, 0, 1, 10, 1999, 2000, 5000} {
+		got, err := TopKChannel(context.Background(), produce(parts...), k)
+		if err != nil {
+			t.Fatalf("k=%d: TopKChannel = %v", k, err)
+		}
+		want := sorted[:Clamp(k, 0, len(sorted))]
+		if !slices.Equal(got, want) {
+			t.Errorf("k=%d: got %v, want %v", k, got, want)
+		}
+	}
+}
+
+// Nothing is ever sent and the channel never closes, only the context can
+// end the wait
+func TestChannelSortsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	go func() {
+		in <- 3
+		cancel()
+	}()
+
+	if _, err := SortChannel(ctx, in); !errors.Is(err, context.Canceled) {
+		t.Errorf("SortChannel = %v, want context.Canceled", err)
+	}
+
+	if _, err := TopKChannel(ctx, make(chan int), 5); !errors.Is(err, context.Canceled) {
+		t.Errorf("TopKChannel = %v, want context.Canceled", err)
+	}
+}
+
+// Sends vec, sleeping delay before every value, then closes
+func sortedProducer(vec []int, delay time.Duration) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for _, val := range vec {
+			time.Sleep(delay)
+			ch <- val
+		}
+	}()
+	return ch
+}
+
+func TestMergeKChannels(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs [][]int
+	}{
+		{"none", nil},
+		{"one", [][]int{{1, 2, 3}}},
+		{"staggered", [][]int{{1, 4, 7, 10, 13}, {2, 5, 8}, {0, 3, 6, 9, 12, 15, 18}}},
+		{"some empty", [][]int{{}, {5, 6}, {}, {1, 9}}},
+		{"duplicates", [][]int{{1, 1, 2}, {1, 2, 2}, {2, 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ins []<-chan int
+			var all []int
+			for i, vec := range tt.inputs {
+				// every producer at its own pace, so they close at different times
+				ins = append(ins, sortedProducer(vec, time.Duration(i)*time.Millisecond))
+				all = append(all, vec...)
+			}
+
+			var got []int
+			for val := range MergeKChannels(ins...) {
+				got = append(got, val)
+			}
+			// the range above only ends once the output is closed
+			if want := slices.Sorted(slices.Values(all)); !slices.Equal(got, want) {
+				t.Errorf("MergeKChannels = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package algorithms
+
+// Same algorithms as in algorithms.go, but with a three-way comparator
+// like the one slices.SortFunc takes. cmp(a, b) should return a negative
+// number when a < b, a positive number when a > b and zero otherwise,
+// so cmp.Compare works out of the box.
+
+func InsertionSortCmp[T any](vec []T, cmp func(a, b T) int) {
+	for i := 1; i < len(vec); i++ {
+		for j := i; j > 0 && cmp(vec[j], vec[j-1]) < 0; j-- {
+			vec[j], vec[j-1] = vec[j-1], vec[j]
+		}
+	}
+}
+
+// If cmp isn't consistent (e.g. it says a < b and b < a) the result is in
+// no particular order but it is still a permutation of vec, it never panics.
+// The partition only ever touches indices between start and end no matter
+// what cmp returns, and the recursion only goes into the smaller side so the
+// stack stays O(log n) even if every pivot is terrible
+func QuickSortCmp[T any](vec []T, cmp func(a, b T) int) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	quickSortCmpHelper(vec, 0, len(vec)-1, cmp)
+}
+
+func quickSortCmpHelper[T any](vec []T, start int, end int, cmp func(a, b T) int) {
+	for start < end {
+		pivot := partitionCmp(vec, start, end, cmp)
+		if pivot-start < end-pivot {
+			quickSortCmpHelper(vec, start, pivot-1, cmp)
+			start = pivot + 1
+		} else {
+			quickSortCmpHelper(vec, pivot+1, end, cmp)
+			end = pivot - 1
+		}
+	}
+}
+
+func partitionCmp[T any](vec []T, start int, end int, cmp func(a, b T) int) int {
+	mid := start + (end-start)/2
+	pivotIndex := medianOfThreeCmp(vec, start, mid, end, cmp)
+	vec[pivotIndex], vec[end] = vec[end], vec[pivotIndex]
+
+	pivot := vec[end]
+	i := start - 1
+
+	for j := start; j < end; j++ {
+		if cmp(vec[j], pivot) <= 0 {
+			i++
+			vec[i], vec[j] = vec[j], vec[i]
+		}
+	}
+
+	vec[i+1], vec[end] = vec[end], vec[i+1]
+	return i + 1
+}
+
+func medianOfThreeCmp[T any](vec []T, i, j, k int, cmp func(a, b T) int) int {
+	if (cmp(vec[i], vec[j]) > 0) != (cmp(vec[i], vec[k]) > 0) {
+		return i
+	} else if (cmp(vec[j], vec[i]) > 0) != (cmp(vec[j], vec[k]) > 0) {
+		return j
+	} else {
+		return k
+	}
+}
+
+// Stable, so equal elements keep their original order just like
+// slices.SortStableFunc
+func MergeSortCmp[T any](vec []T, cmp func(a, b T) int) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	tmp := make([]T, len(vec))
+	mergeSortCmpHelper(vec, tmp, 0, len(vec)-1, 0, cmp)
+}
+
+// Ranges of at most cutoff elements are insertion sorted instead of split,
+// 0 splits all the way down
+func mergeSortCmpHelper[T any](vec []T, tmp []T, start int, end int, cutoff int, cmp func(a, b T) int) {
+	if start >= end {
+		return
+	}
+	if end-start+1 <= cutoff {
+		InsertionSortCmp(vec[start:end+1], cmp)
+		return
+	}
+
+	mid := start + (end-start)/2
+	mergeSortCmpHelper(vec, tmp, start, mid, cutoff, cmp)
+	mergeSortCmpHelper(vec, tmp, mid+1, end, cutoff, cmp)
+	mergeCmp(vec, tmp, start, mid, end, cmp)
+}
+
+func mergeCmp[T any](vec []T, tmp []T, start int, mid int, end int, cmp func(a, b T) int) {
+	i, j, k := start, mid+1, start
+
+	for i <= mid && j <= end {
+		// <= keeps it stable, left side wins ties
+		if cmp(vec[i], vec[j]) <= 0 {
+			tmp[k] = vec[i]
+			i++
+		} else {
+			tmp[k] = vec[j]
+			j++
+		}
+		k++
+	}
+
+	for i <= mid {
+		tmp[k] = vec[i]
+		i++
+		k++
+	}
+
+	for j <= end {
+		tmp[k] = vec[j]
+		j++
+		k++
+	}
+
+	for i = start; i <= end; i++ {
+		vec[i] = tmp[i]
+	}
+}
+
+// Stable merge sort with a comparator that can fail. The first error stops
+// the sort and is returned as is. vec is left partially sorted in that case,
+// but it still holds exactly the same elements since a merge only writes back
+// once it is done. Keeping cmp consistent is up to the caller.
+func SortFuncErr[T any](vec []T, cmp func(a, b T) (int, error)) error {
+	if len(vec) <= 1 {
+		return nil
+	}
+
+	tmp := make([]T, len(vec))
+	return mergeSortErrHelper(vec, tmp, 0, len(vec)-1, cmp)
+}
+
+func mergeSortErrHelper[T any](vec []T, tmp []T, start int, end int, cmp func(a, b T) (int, error)) error {
+	if start >= end {
+		return nil
+	}
+
+	mid := start + (end-start)/2
+	if err := mergeSortErrHelper(vec, tmp, start, mid, cmp); err != nil {
+		return err
+	}
+	if err := mergeSortErrHelper(vec, tmp, mid+1, end, cmp); err != nil {
+		return err
+	}
+	return mergeErr(vec, tmp, start, mid, end, cmp)
+}
+
+func mergeErr[T any](vec []T, tmp []T, start int, mid int, end int, cmp func(a, b T) (int, error)) error {
+	i, j, k := start, mid+1, start
+
+	for i <= mid && j <= end {
+		c, err := cmp(vec[i], vec[j])
+		if err != nil {
+			return err
+		}
+
+		if c <= 0 {
+			tmp[k] = vec[i]
+			i++
+		} else {
+			tmp[k] = vec[j]
+			j++
+		}
+		k++
+	}
+
+	for i <= mid {
+		tmp[k] = vec[i]
+		i++
+		k++
+	}
+
+	for j <= end {
+		tmp[k] = vec[j]
+		j++
+		k++
+	}
+
+	for i = start; i <= end; i++ {
+		vec[i] = tmp[i]
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package algorithms
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSortCmpMatchesSlicesSortFunc(t *testing.T) {
+	sorts := []struct {
+		name string
+		sort func([]int, func(a, b int) int)
+	}{
+		{"QuickSortCmp", QuickSortCmp[int]},
+		{"MergeSortCmp", MergeSortCmp[int]},
+		{"InsertionSortCmp", InsertionSortCmp[int]},
+	}
+	cmps := []struct {
+		name string
+		cmp  func(a, b int) int
+	}{
+		{"cmp.Compare", cmp.Compare[int]},
+		{"descending", func(a, b int) int { return cmp.Compare(b, a) }},
+		{"by last digit", func(a, b int) int { return cmp.Compare(a%10, b%10) }},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	inputs := map[string][]int{
+		"empty":      {},
+		"single":     {1},
+		"random":     RandomInts(1000, rng),
+		"sorted":     SortedInts(1000),
+		"reverse":    ReverseSortedInts(1000),
+		"few unique": FewUniqueInts(1000, rng),
+	}
+
+	for _, s := range sorts {
+		for _, c := range cmps {
+			for inputName, in := range inputs {
+				got := slices.Clone(in)
+				s.sort(got, c.cmp)
+				want := slices.Clone(in)
+				slices.SortFunc(want, c.cmp)
+
+				// equal under cmp is all that can be asked of an unstable sort
+				if slices.CompareFunc(got, want, c.cmp) != 0 {
+					t.Errorf("%s with %s on %s doesn't match slices.SortFunc", s.name, c.name, inputName)
+				}
+			}
+		}
+	}
+}
+
+func TestMergeSortCmpMatchesSortStableFunc(t *testing.T) {
+	words := strings.Fields("pear fig apple kiwi plum date lime grape peach melon lemon mango")
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+
+	got := slices.Clone(words)
+	MergeSortCmp(got, byLen)
+	want := slices.Clone(words)
+	slices.SortStableFunc(want, byLen)
+
+	if !slices.Equal(got, want) {
+		t.Errorf("MergeSortCmp = %q, want %q", got, want)
+	}
+}
+
+// A comparator that isn't a strict weak ordering can't make any of the
+// comparator sorts index out of range or lose elements, the order it leaves
+// is just whatever it is
+func TestSortCmpInconsistent(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	comparators := []struct {
+		name string
+		cmp  func(a, b int) int
+	}{
+		{"random", func(a, b int) int { return rng.Intn(3) - 1 }},
+		{"always less", func(a, b int) int { return -1 }},
+		{"always greater", func(a, b int) int { return 1 }},
+		{"always equal", func(a, b int) int { return 0 }},
+		{"not antisymmetric", func(a, b int) int {
+			if a%3 == b%3 {
+				return -1
+			}
+			return cmp.Compare(a, b)
+		}},
+	}
+
+	sorts := []struct {
+		name string
+		sort func([]int, func(a, b int) int)
+	}{
+		{"QuickSortCmp", QuickSortCmp[int]},
+		{"MergeSortCmp", MergeSortCmp[int]},
+		{"InsertionSortCmp", InsertionSortCmp[int]},
+		{"FrequencySortFunc", FrequencySortFunc[int]},
+	}
+
+	for _, c := range comparators {
+		for _, s := range sorts {
+			t.Run(s.name+"/"+c.name, func(t *testing.T) {
+				for _, n := range []int{2, 3, 17, 1000} {
+					vec := RandomInts(n, rng)
+					want := slices.Sorted(slices.Values(vec))
+
+					s.sort(vec, c.cmp)
+
+					slices.Sort(vec)
+					if !slices.Equal(vec, want) {
+						t.Fatalf("n=%d: changed the elements", n)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestSortFuncErr(t *testing.T) {
+	errBad := errors.New("malformed record")
+	// records are numbers in strings, "x" can't be parsed
+	parse := func(s string) (int, error) {
+		var n int
+		if _, err := fmt.Sscan(s, &n); err != nil {
+			return 0, fmt.Errorf("%q: %w", s, errBad)
+		}
+		return n, nil
+	}
+	byNumber := func(a, b string) (int, error) {
+		x, err := parse(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := parse(b)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(x, y), nil
+	}
+
+	tests := []struct {
+		name    string
+		vec     []string
+		want    []string
+		wantErr error
+	}{
+		{"empty", nil, nil, nil},
+		{"single bad", []string{"x"}, []string{"x"}, nil},
+		{"all good", []string{"10", "2", "33", "2", "1"}, []string{"1", "2", "2", "10", "33"}, nil},
+		{"bad first", []string{"x", "2", "33", "1"}, nil, errBad},
+		{"bad midway", []string{"5", "4", "3", "2", "1", "x", "7", "6"}, nil, errBad},
+		{"bad last", []string{"5", "4", "3", "x"}, nil, errBad},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			err := SortFuncErr(vec, byNumber)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SortFuncErr = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && !slices.Equal(vec, tt.want) {
+				t.Errorf("SortFuncErr = %q, want %q", vec, tt.want)
+			}
+			// partially sorted after an error, but nothing lost
+			if !slices.Equal(slices.Sorted(slices.Values(vec)), slices.Sorted(slices.Values(tt.vec))) {
+				t.Errorf("SortFuncErr changed the elements: %q", vec)
+			}
+		})
+	}
+}
+
+// The first error ends the sort, cmp isn't called again after it
+func TestSortFuncErrStopsAtFirstError(t *testing.T) {
+	errStop := errors.New("stop")
+	calls := 0
+	err := SortFuncErr(RandomInts(1000, rand.New(rand.NewSource(1))), func(a, b int) (int, error) {
+		calls++
+		if calls == 100 {
+			return 0, errStop
+		}
+		return cmp.Compare(a, b), nil
+	})
+
+	if err != errStop {
+		t.Fatalf("SortFuncErr = %v, want the comparator's error as is", err)
+	}
+	if calls != 100 {
+		t.Errorf("cmp was called %d times, want it to stop at 100", calls)
+	}
+}
@@ -0,0 +1,61 @@
+package algorithms
+
+import "cmp"
+
+// Building blocks for comparators that can be passed to MergeSortCmp and
+// friends, e.g. to sort by last name and then by age, oldest first:
+//
+//	Then(ByField(func(p Person) string { return p.LastName }),
+//		ReverseCmp(ByField(func(p Person) int { return p.Age })))
+
+// Compares by the key that key extracts
+func ByField[T any, K Ordered](key func(T) K) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// Flips the order of compare. Named ReverseCmp since Reverse already reverses
+// slices
+func ReverseCmp[T any](compare func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		return compare(b, a)
+	}
+}
+
+// Tries each comparator in turn, the first one that doesn't call it a tie
+// decides
+func Then[T any](cmps ...func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		for _, compare := range cmps {
+			if c := compare(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// One key of a SortColumns sort, like one column in an SQL ORDER BY
+type ColumnSpec[T any] struct {
+	Compare    func(a, b T) int
+	Descending bool
+}
+
+// Sorts rows by the first column, ties by the second and so on, each one in
+// its own direction, so it's ORDER BY a ASC, b DESC with
+//
+//	[]ColumnSpec[Row]{{Compare: byA}, {Compare: byB, Descending: true}}
+//
+// Stable, rows that are equal in every column keep their order
+func SortColumns[T any](vec []T, cols []ColumnSpec[T]) {
+	cmps := make([]func(a, b T) int, len(cols))
+	for i, col := range cols {
+		cmps[i] = col.Compare
+		if col.Descending {
+			cmps[i] = ReverseCmp(col.Compare)
+		}
+	}
+
+	MergeSortCmp(vec, Then(cmps...))
+}
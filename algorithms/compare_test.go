@@ -0,0 +1,116 @@
+package algorithms
+
+import (
+	"slices"
+	"testing"
+)
+
+type person struct {
+	First, Last string
+	Age         int
+}
+
+func TestComparators(t *testing.T) {
+	people := []person{
+		{"Ada", "Lovelace", 36},
+		{"Alan", "Turing", 41},
+		{"Byron", "Lovelace", 60},
+		{"Grace", "Hopper", 85},
+		{"Annie", "Turing", 41},
+		{"Anne", "Lovelace", 36},
+	}
+	lastName := ByField(func(p person) string { return p.Last })
+	age := ByField(func(p person) int { return p.Age })
+
+	tests := []struct {
+		name    string
+		compare func(a, b person) int
+		want    []string
+	}{
+		{"by last name", lastName, []string{"Grace", "Ada", "Byron", "Anne", "Alan", "Annie"}},
+		{"by age", age, []string{"Ada", "Anne", "Alan", "Annie", "Byron", "Grace"}},
+		{"oldest first", ReverseCmp(age), []string{"Grace", "Byron", "Alan", "Annie", "Ada", "Anne"}},
+		{"last name then oldest first", Then(lastName, ReverseCmp(age)), []string{"Grace", "Byron", "Ada", "Anne", "Alan", "Annie"}},
+		{"reversed composition", ReverseCmp(Then(lastName, age)), []string{"Alan", "Annie", "Byron", "Ada", "Anne", "Grace"}},
+		// no comparators, everything ties and the stable sort leaves it alone
+		{"empty Then", Then[person](), []string{"Ada", "Alan", "Byron", "Grace", "Annie", "Anne"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(people)
+			MergeSortCmp(vec, tt.compare)
+
+			var got []string
+			for _, p := range vec {
+				got = append(got, p.First)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("sorted %s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+// Then stops at the first comparator that isn't a tie
+func TestThenShortCircuits(t *testing.T) {
+	calls := 0
+	counted := func(a, b int) int {
+		calls++
+		return 0
+	}
+	first := func(a, b int) int { return a - b }
+
+	if c := Then(first, counted)(1, 2); c >= 0 || calls != 0 {
+		t.Errorf("Then = %d after %d calls of the second comparator, want < 0 after 0", c, calls)
+	}
+	if c := Then(first, counted)(2, 2); c != 0 || calls != 1 {
+		t.Errorf("Then = %d after %d calls of the second comparator, want 0 after 1", c, calls)
+	}
+}
+
+// ORDER BY name ASC, score DESC, rows equal in both keep their input order
+func TestSortColumns(t *testing.T) {
+	type row struct {
+		Name  string
+		Score int
+		ID    int
+	}
+	rows := []row{
+		{"bob", 70, 0},
+		{"alice", 90, 1},
+		{"bob", 95, 2},
+		{"alice", 60, 3},
+		{"carol", 80, 4},
+		{"alice", 90, 5},
+		{"bob", 70, 6},
+	}
+	name := ByField(func(r row) string { return r.Name })
+	score := ByField(func(r row) int { return r.Score })
+
+	tests := []struct {
+		name string
+		cols []ColumnSpec[row]
+		want []int
+	}{
+		{"name asc, score desc", []ColumnSpec[row]{{Compare: name}, {Compare: score, Descending: true}}, []int{1, 5, 3, 2, 0, 6, 4}},
+		{"name desc, score asc", []ColumnSpec[row]{{Compare: name, Descending: true}, {Compare: score}}, []int{4, 0, 6, 2, 3, 1, 5}},
+		{"score desc only", []ColumnSpec[row]{{Compare: score, Descending: true}}, []int{2, 1, 5, 4, 0, 6, 3}},
+		{"no columns", nil, []int{0, 1, 2, 3, 4, 5, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(rows)
+			SortColumns(vec, tt.cols)
+
+			var got []int
+			for _, r := range vec {
+				got = append(got, r.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SortColumns = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package algorithms
+
+import "slices"
+
+// Every other sort in this package sorts in place. These return a sorted
+// copy instead and don't touch vec, which is handy when vec is shared.
+
+// Sorted copy of vec, using Sort
+func CopySort[T Ordered](vec []T) []T {
+	return CopySortWith(vec, Sort[T])
+}
+
+// Sorted copy of vec ordered by cmp, stable
+func CopySortFunc[T any](vec []T, cmp func(a, b T) int) []T {
+	out := slices.Clone(vec)
+	MergeSortCmp(out, cmp)
+	return out
+}
+
+// Sorted copy of vec using any of the in place sorts, e.g.
+// CopySortWith(vec, HeapSort[int])
+func CopySortWith[T any](vec []T, sort func([]T)) []T {
+	out := slices.Clone(vec)
+	sort(out)
+	return out
+}
+
+// Sorted copy of only the elements of vec that keep returns true for. The
+// filtering is done while copying, into a result with room for all of vec,
+// so it's allocated once and never grows. Never nil, empty if nothing was
+// kept
+func SortFiltered[T Ordered](vec []T, keep func(T) bool) []T {
+	out := make([]T, 0, len(vec))
+	for _, val := range vec {
+		if keep(val) {
+			out = append(out, val)
+		}
+	}
+
+	Sort(out)
+	return out
+}
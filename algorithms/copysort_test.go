@@ -0,0 +1,95 @@
+package algorithms
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestCopySortLeavesInputAlone(t *testing.T) {
+	in := RandomInts(1000, rand.New(rand.NewSource(1)))
+	want := slices.Sorted(slices.Values(in))
+
+	tests := []struct {
+		name string
+		sort func([]int) []int
+	}{
+		{"CopySort", CopySort[int]},
+		{"CopySortFunc", func(v []int) []int { return CopySortFunc(v, cmp.Compare[int]) }},
+		{"CopySortWith HeapSort", func(v []int) []int { return CopySortWith(v, HeapSort[int]) }},
+		{"CopySortWith slices.Sort", func(v []int) []int { return CopySortWith(v, slices.Sort[[]int]) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(in)
+			got := tt.sort(vec)
+			if !slices.Equal(vec, in) {
+				t.Errorf("%s changed its input", tt.name)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("%s didn't sort the copy", tt.name)
+			}
+			// a real copy, not a view of the input
+			if len(got) > 0 && &got[0] == &vec[0] {
+				t.Errorf("%s result shares memory with its input", tt.name)
+			}
+		})
+	}
+}
+
+func TestSortFiltered(t *testing.T) {
+	even := func(x int) bool { return x%2 == 0 }
+	in := RandomInts(1000, rand.New(rand.NewSource(1)))
+
+	tests := []struct {
+		name string
+		vec  []int
+		keep func(int) bool
+		want []int
+	}{
+		{"evens", []int{7, 4, 1, 8, 2, 9, 4, 3}, even, []int{2, 4, 4, 8}},
+		{"negative evens", []int{-3, -4, 6, 0, -2}, even, []int{-4, -2, 0, 6}},
+		{"keep everything", []int{3, 1, 2}, func(int) bool { return true }, []int{1, 2, 3}},
+		{"keep nothing", []int{3, 1, 2}, func(int) bool { return false }, []int{}},
+		{"empty", nil, even, []int{}},
+		{"random", in, even, func() []int {
+			var want []int
+			for _, val := range in {
+				if even(val) {
+					want = append(want, val)
+				}
+			}
+			slices.Sort(want)
+			return want
+		}()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			got := SortFiltered(vec, tt.keep)
+			if got == nil || !slices.Equal(got, tt.want) {
+				t.Errorf("SortFiltered(%v) = %v, want %v", tt.vec, got, tt.want)
+			}
+			if !slices.Equal(vec, tt.vec) {
+				t.Errorf("SortFiltered changed its input to %v", vec)
+			}
+		})
+	}
+}
+
+// Filtering into the result doesn't grow it. Small enough that Sort goes
+// straight to InsertionSort, which doesn't allocate either
+func TestSortFilteredAllocatesOnce(t *testing.T) {
+	vec := ReverseSortedInts(SmallSortThreshold)
+	for _, keep := range []func(int) bool{
+		func(int) bool { return true },
+		func(x int) bool { return x%3 != 0 },
+	} {
+		if allocs := testing.AllocsPerRun(10, func() { SortFiltered(vec, keep) }); allocs != 1 {
+			t.Errorf("SortFiltered made %v allocations, want 1", allocs)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package algorithms
+
+import (
+	"context"
+	"time"
+)
+
+// SortContext checks ctx once per this many elements merged, so the checks
+// don't show up next to the actual sorting
+const ContextCheckInterval = 1 << 14
+
+// MergeSortBottomUp that gives up once ctx is done and returns ctx.Err().
+// Merges are never interrupted halfway, so vec is left partially sorted but
+// still holds exactly the same elements
+func SortContext[T Ordered](ctx context.Context, vec []T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if len(vec) <= 1 {
+		return nil
+	}
+
+	return mergePasses(vec, ctx.Err)
+}
+
+// Sorts vec but stops once d has passed, returning context.DeadlineExceeded.
+// See SortContext for what vec looks like then
+func SortDeadline[T Ordered](vec []T, d time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	return SortContext(ctx, vec)
+}
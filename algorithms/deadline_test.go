@@ -0,0 +1,101 @@
+package algorithms
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSortContext(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		n       int
+		wantErr error
+	}{
+		{"background", context.Background(), 100_000, nil},
+		{"background short", context.Background(), 10, nil},
+		{"cancelled", cancelled, 100_000, context.Canceled},
+		{"cancelled empty", cancelled, 0, context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := RandomInts(tt.n, rand.New(rand.NewSource(1)))
+			want := slices.Sorted(slices.Values(vec))
+
+			err := SortContext(tt.ctx, vec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SortContext = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !slices.Equal(vec, want) {
+				t.Errorf("SortContext returned nil but didn't sort")
+			}
+
+			// interrupted or not, no element is lost or duplicated
+			slices.Sort(vec)
+			if !slices.Equal(vec, want) {
+				t.Errorf("SortContext changed the elements of vec")
+			}
+		})
+	}
+}
+
+// A cancel partway through the passes stops the sort at the very next check
+func TestSortContextCancelledMidway(t *testing.T) {
+	vec := RandomInts(1<<22, rand.New(rand.NewSource(1)))
+	merged := 0
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := mergePasses(vec, func() error {
+		merged++
+		if merged == 3 {
+			cancel()
+		}
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("mergePasses = %v, want context.Canceled", err)
+	}
+	if merged != 3 {
+		t.Errorf("mergePasses kept going for %d checks after the cancel", merged-3)
+	}
+}
+
+// A deadline that has already passed is caught by the check before the
+// first merge, so vec isn't touched at all. How soon a running sort notices
+// is TestSortContextCancelledMidway's job, it counts the checks
+func TestSortDeadline(t *testing.T) {
+	in := RandomInts(1<<20, rand.New(rand.NewSource(1)))
+
+	for _, d := range []time.Duration{0, -time.Second} {
+		vec := slices.Clone(in)
+		if err := SortDeadline(vec, d); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("SortDeadline(%v) = %v, want context.DeadlineExceeded", d, err)
+		}
+		if !slices.Equal(vec, in) {
+			t.Errorf("SortDeadline(%v) merged before noticing the deadline", d)
+		}
+	}
+
+	// a nanosecond is gone long before a million elements are merged
+	vec := slices.Clone(in)
+	if err := SortDeadline(vec, time.Nanosecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SortDeadline(1ns) = %v, want context.DeadlineExceeded", err)
+	}
+	slices.Sort(vec)
+	if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+		t.Errorf("SortDeadline(1ns) changed the elements of vec")
+	}
+
+	vec = RandomInts(1000, rand.New(rand.NewSource(1)))
+	if err := SortDeadline(vec, time.Minute); err != nil || !slices.IsSorted(vec) {
+		t.Errorf("SortDeadline with plenty of time = %v, sorted %v", err, slices.IsSorted(vec))
+	}
+}
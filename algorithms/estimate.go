@@ -0,0 +1,51 @@
+package algorithms
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// How many digit passes IntRadixSort makes over vec: one per decimal digit of
+// the max value, and none for slices of less than 2 elements or when
+// everything is 0. Each pass is O(n + NumDigits)
+func EstimateRadixPasses(vec []uint) int {
+	if len(vec) <= 1 {
+		return 0
+	}
+
+	passes := 0
+	for max := slices.Max(vec); max > 0; max /= NumDigits {
+		passes++
+	}
+	return passes
+}
+
+// Rough cost of sorting n elements with the algorithm called algo in
+// Sorters, in comparisons. It's the big O of the average case with the
+// constants left out (n^2, n log n or n log^2 n, log rounded up), so it is
+// only good for comparing algorithms with each other, not for predicting a
+// time. -1 if there is no algorithm with that name
+func EstimateWork(algo string, n int) int64 {
+	if n <= 1 {
+		if _, ok := Sorters[int]()[algo]; !ok {
+			return -1
+		}
+		return 0
+	}
+
+	size := int64(n)
+	log := int64(bits.Len(uint(n - 1)))
+
+	switch algo {
+	case "simple", "selection", "bubble", "oddeven", "insertion":
+		return size * size
+	case "bitonic", "symmerge":
+		// bitonic does that many comparisons, symmerge that many moves
+		return size * log * log
+	case "merge", "mergebu", "blockmerge", "quick", "threeway", "dualpivot",
+		"intro", "pdq", "heap", "sample", "adaptive", "sort":
+		return size * log
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,72 @@
+package algorithms
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestEstimateRadixPasses(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name string
+		vec  []uint
+		want int
+	}{
+		{"empty", nil, 0},
+		{"single", []uint{12345}, 0},
+		{"all zero", []uint{0, 0, 0}, 0},
+		{"one digit", []uint{9, 0, 3}, 1},
+		{"power of ten", []uint{10, 1}, 2},
+		{"just under", []uint{999, 5}, 3},
+		{"max uint", []uint{^uint(0), 0}, 20},
+		{"random", func() []uint {
+			v := make([]uint, 1000)
+			for i := range v {
+				v[i] = uint(rng.Intn(1_000_000))
+			}
+			v[0] = 1_000_000
+			return v
+		}(), 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EstimateRadixPasses(tt.vec)
+			if got != tt.want {
+				t.Errorf("EstimateRadixPasses = %d, want %d", got, tt.want)
+			}
+
+			// the estimate has to be what the sort really does, both ways
+			for _, desc := range []bool{false, true} {
+				vec := append([]uint(nil), tt.vec...)
+				if passes := intRadixSort(vec, desc); passes != got {
+					t.Errorf("desc=%v: intRadixSort made %d passes, estimated %d", desc, passes, got)
+				}
+			}
+		})
+	}
+}
+
+func TestEstimateWork(t *testing.T) {
+	for name := range Sorters[int]() {
+		if EstimateWork(name, 1000) <= 0 {
+			t.Errorf("EstimateWork(%q, 1000) = %d", name, EstimateWork(name, 1000))
+		}
+		if EstimateWork(name, 1) != 0 {
+			t.Errorf("EstimateWork(%q, 1) = %d, want 0", name, EstimateWork(name, 1))
+		}
+	}
+
+	if got := EstimateWork("nope", 1000); got != -1 {
+		t.Errorf("EstimateWork of an unknown algorithm = %d, want -1", got)
+	}
+	if got := EstimateWork("nope", 0); got != -1 {
+		t.Errorf("EstimateWork of an unknown algorithm on no input = %d, want -1", got)
+	}
+
+	// n log n has to come out ahead of n^2 and n log^2 n
+	quick, bitonic, insertion := EstimateWork("quick", 1<<20), EstimateWork("bitonic", 1<<20), EstimateWork("insertion", 1<<20)
+	if quick != 20<<20 || !(quick < bitonic && bitonic < insertion) {
+		t.Errorf("EstimateWork on 2^20: quick %d, bitonic %d, insertion %d", quick, bitonic, insertion)
+	}
+}
@@ -0,0 +1,192 @@
+package algorithms
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"strconv"
+)
+
+// Default number of integers sorted in memory at once, 8MB worth of int64s
+const DefaultChunkSize = 1 << 20
+
+type ExternalSortOptions struct {
+	// How many integers are held in memory at once. Defaults to
+	// DefaultChunkSize when <= 0
+	ChunkSize int
+	// Where the sorted runs are spilled to. Defaults to os.TempDir()
+	TempDir string
+}
+
+// Sorts a stream of whitespace separated integers that doesn't have to fit
+// in memory. The input is read in chunks of opts.ChunkSize, every chunk is
+// sorted with MergeSort and spilled to a temp file, and then all the runs
+// are merged straight into w, one integer per line. The temp files are
+// removed before returning, also when something fails.
+func ExternalSort(r io.Reader, w io.Writer, opts ExternalSortOptions) (err error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	var runs []*os.File
+	defer func() {
+		for _, f := range runs {
+			closeErr := f.Close()
+			removeErr := os.Remove(f.Name())
+			if err == nil {
+				err = errors.Join(closeErr, removeErr)
+			}
+		}
+	}()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	chunk := make([]int64, 0, chunkSize)
+
+	for scanner.Scan() {
+		val, err := strconv.ParseInt(scanner.Text(), 10, 64)
+		if err != nil {
+			return err
+		}
+
+		chunk = append(chunk, val)
+		if len(chunk) == chunkSize {
+			run, err := spillRun(chunk, opts.TempDir)
+			if run != nil {
+				runs = append(runs, run)
+			}
+			if err != nil {
+				return err
+			}
+			chunk = chunk[:0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	var line []byte
+	emit := func(val int64) error {
+		line = strconv.AppendInt(line[:0], val, 10)
+		line = append(line, '\n')
+		_, err := out.Write(line)
+		return err
+	}
+
+	// Everything fit in one chunk, no need to touch the disk
+	if len(runs) == 0 {
+		MergeSort(chunk)
+		for _, val := range chunk {
+			if err := emit(val); err != nil {
+				return err
+			}
+		}
+		return out.Flush()
+	}
+
+	if len(chunk) > 0 {
+		run, err := spillRun(chunk, opts.TempDir)
+		if run != nil {
+			runs = append(runs, run)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	chunk = nil
+
+	readers := make([]*bufio.Reader, len(runs))
+	for i, f := range runs {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		readers[i] = bufio.NewReader(f)
+	}
+
+	var buf [8]byte
+	next := func(src int) (int64, bool, error) {
+		_, err := io.ReadFull(readers[src], buf[:])
+		if err == io.EOF {
+			return 0, false, nil
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		return int64(binary.LittleEndian.Uint64(buf[:])), true, nil
+	}
+
+	if err := kWayMerge(len(runs), next, emit); err != nil {
+		return err
+	}
+
+	return out.Flush()
+}
+
+// Sorts chunk and writes it to a new temp file as raw little endian int64s.
+// The file is returned even on error so the caller can clean it up
+func spillRun(chunk []int64, dir string) (*os.File, error) {
+	MergeSort(chunk)
+
+	f, err := os.CreateTemp(dir, "external-sort-run-*")
+	if err != nil {
+		return nil, err
+	}
+
+	bw := bufio.NewWriter(f)
+	var buf [8]byte
+	for _, val := range chunk {
+		binary.LittleEndian.PutUint64(buf[:], uint64(val))
+		if _, err := bw.Write(buf[:]); err != nil {
+			return f, err
+		}
+	}
+
+	return f, bw.Flush()
+}
+
+// The k largest of a stream of whitespace separated unsigned integers, in
+// ascending order. Only a min-heap of the k largest so far is kept, so it
+// is O(n log k) time and O(k) memory no matter how big the stream is, and
+// nothing is spilled to disk. Fewer than k numbers in the stream just returns
+// all of them. A token that isn't an unsigned integer stops it with an error
+// saying which token it was
+func ExternalTopK(r io.Reader, k int) ([]uint, error) {
+	if k < 0 {
+		k = 0
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	heap := make([]uint, 0, k)
+
+	for n := 0; scanner.Scan(); n++ {
+		parsed, err := strconv.ParseUint(scanner.Text(), 10, bits.UintSize)
+		if err != nil {
+			return nil, fmt.Errorf("algorithms: ExternalTopK token %d: %w", n, err)
+		}
+		val := uint(parsed)
+
+		if len(heap) < k {
+			heap = append(heap, val)
+			if len(heap) == k {
+				BuildMinHeap(heap)
+			}
+		} else if k > 0 && val > heap[0] {
+			// root is the smallest of the k largest so far, so it goes
+			heap[0] = val
+			MinHeapify(heap, 0, k)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	HeapSort(heap)
+	return heap, nil
+}
@@ -0,0 +1,234 @@
+package algorithms
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Random int64s as ExternalSort reads them, and what it should write out
+func externalSortInput(n int, rng *rand.Rand) (input string, want string) {
+	vals := make([]int64, n)
+	var in strings.Builder
+	for i := range vals {
+		vals[i] = rng.Int63n(2000) - 1000
+		// any whitespace separates numbers
+		sep := []string{" ", "\n", "\t", "  \r\n"}[i%4]
+		fmt.Fprintf(&in, "%d%s", vals[i], sep)
+	}
+
+	slices.Sort(vals)
+	var out strings.Builder
+	for _, val := range vals {
+		fmt.Fprintf(&out, "%d\n", val)
+	}
+	return in.String(), out.String()
+}
+
+// Fails if ExternalSort left any of its runs behind
+func checkNoRuns(t *testing.T, dir string) {
+	t.Helper()
+	left, err := filepath.Glob(filepath.Join(dir, "external-sort-run-*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Errorf("runs left in the temp dir: %v", left)
+	}
+}
+
+func TestExternalSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name      string
+		n         int
+		chunkSize int
+	}{
+		{"fits in one chunk", 1000, 0},
+		{"exactly one chunk", 100, 100},
+		{"several runs", 10_000, 1000},
+		{"last run short", 10_001, 1000},
+		{"many tiny runs", 500, 3},
+		{"runs of one", 50, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			input, want := externalSortInput(tt.n, rng)
+
+			var out strings.Builder
+			err := ExternalSort(strings.NewReader(input), &out, ExternalSortOptions{ChunkSize: tt.chunkSize, TempDir: dir})
+			if err != nil {
+				t.Fatalf("ExternalSort = %v", err)
+			}
+			if out.String() != want {
+				t.Errorf("ExternalSort output doesn't match slices.Sort")
+			}
+			checkNoRuns(t, dir)
+		})
+	}
+}
+
+// Writes fine until limit bytes went through
+type failingWriter struct {
+	limit int
+}
+
+var errWriteFailed = errors.New("write failed")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		n := w.limit
+		w.limit = 0
+		return n, errWriteFailed
+	}
+	w.limit -= len(p)
+	return len(p), nil
+}
+
+// Failing halfway, after runs were already spilled, still cleans them up
+func TestExternalSortErrors(t *testing.T) {
+	input, _ := externalSortInput(10_000, rand.New(rand.NewSource(1)))
+
+	tests := []struct {
+		name    string
+		input   string
+		w       io.Writer
+		wantErr error
+	}{
+		{"malformed token", input + " 12x " + input, io.Discard, strconv.ErrSyntax},
+		{"out of range", input + " 99999999999999999999", io.Discard, strconv.ErrRange},
+		{"failing writer", input, &failingWriter{limit: 10_000}, errWriteFailed},
+		{"writer fails right away", input, &failingWriter{}, errWriteFailed},
+	}
+
+	for _, tt := range tests {
+		for _, chunkSize := range []int{0, 1000} {
+			t.Run(fmt.Sprintf("%s/chunk %d", tt.name, chunkSize), func(t *testing.T) {
+				dir := t.TempDir()
+				err := ExternalSort(strings.NewReader(tt.input), tt.w, ExternalSortOptions{ChunkSize: chunkSize, TempDir: dir})
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ExternalSort = %v, want %v", err, tt.wantErr)
+				}
+				checkNoRuns(t, dir)
+			})
+		}
+	}
+
+	// a temp dir that doesn't exist fails on the first spill
+	missing := filepath.Join(t.TempDir(), "missing")
+	err := ExternalSort(strings.NewReader(input), io.Discard, ExternalSortOptions{ChunkSize: 1000, TempDir: missing})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ExternalSort into a missing dir = %v", err)
+	}
+}
+
+func TestExternalTopK(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		k     int
+		want  []uint
+	}{
+		{"top 3", "5 1 9 3 7 2 8", 3, []uint{7, 8, 9}},
+		{"duplicates", "4 4 1 4 2", 2, []uint{4, 4}},
+		{"fewer than k", "3 1 2", 10, []uint{1, 2, 3}},
+		{"any whitespace", "  10\n2\t\t30 \r\n 4\n", 2, []uint{10, 30}},
+		{"k of zero", "1 2 3", 0, []uint{}},
+		{"negative k", "1 2 3", -1, []uint{}},
+		{"empty", "", 3, []uint{}},
+		{"max uint", "0 18446744073709551615 1", 1, []uint{^uint(0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExternalTopK(strings.NewReader(tt.input), tt.k)
+			if err != nil {
+				t.Fatalf("ExternalTopK = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ExternalTopK(%q, %d) = %v, want %v", tt.input, tt.k, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalTopKMalformed(t *testing.T) {
+	for _, input := range []string{"1 2 x 3", "1 -2 3", "1 2.5", "1 99999999999999999999999"} {
+		_, err := ExternalTopK(strings.NewReader(input), 2)
+		if !errors.Is(err, strconv.ErrSyntax) && !errors.Is(err, strconv.ErrRange) {
+			t.Errorf("ExternalTopK(%q) = %v, want a parse error", input, err)
+		}
+	}
+
+	_, err := ExternalTopK(strings.NewReader("1 2 x 3"), 2)
+	if err == nil || !strings.Contains(err.Error(), "token 2") {
+		t.Errorf("error %v doesn't say which token was bad", err)
+	}
+}
+
+// Writes n random numbers one per Read, only ever holding one of them, and
+// keeps the top k it handed out so far to check against. Every so often it
+// looks at the live heap, which has to stay small since nothing holds on to
+// the stream
+type numberStream struct {
+	rng      *rand.Rand
+	left     int
+	k        int
+	top      []uint
+	peakHeap uint64
+}
+
+func (s *numberStream) Read(p []byte) (int, error) {
+	if s.left == 0 {
+		return 0, io.EOF
+	}
+	s.left--
+
+	val := uint(s.rng.Uint64())
+	if pos, _ := slices.BinarySearch(s.top, val); len(s.top) < s.k {
+		s.top = slices.Insert(s.top, pos, val)
+	} else if pos > 0 {
+		s.top = slices.Insert(s.top[1:], pos-1, val)
+	}
+
+	if s.left%1_000_000 == 0 {
+		// collect first, HeapAlloc counts garbage that is still around too
+		runtime.GC()
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		s.peakHeap = max(s.peakHeap, m.HeapAlloc)
+	}
+
+	return copy(p, strconv.FormatUint(uint64(val), 10)+"\n"), nil
+}
+
+func TestExternalTopKStream(t *testing.T) {
+	if testing.Short() {
+		t.Skip("reads 10M numbers")
+	}
+
+	const n, k = 10_000_000, 100
+	stream := &numberStream{rng: rand.New(rand.NewSource(1)), left: n, k: k}
+	got, err := ExternalTopK(stream, k)
+	if err != nil {
+		t.Fatalf("ExternalTopK = %v", err)
+	}
+	if !slices.Equal(got, stream.top) {
+		t.Fatalf("ExternalTopK = %v, want %v", got, stream.top)
+	}
+
+	// the stream is over 150MB of text, holding on to it would show
+	if stream.peakHeap > 16<<20 {
+		t.Errorf("live heap got to %d bytes", stream.peakHeap)
+	}
+}
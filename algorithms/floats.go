@@ -0,0 +1,70 @@
+package algorithms
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrNaN = errors.New("algorithms: slice contains NaN")
+
+// Sorts vec unless it contains a NaN, in which case it returns ErrNaN and
+// vec is left untouched. -0 goes before +0, same as CompareFloat
+func SortFloatsChecked(vec []float64) error {
+	for _, val := range vec {
+		if math.IsNaN(val) {
+			return ErrNaN
+		}
+	}
+
+	Sort(vec)
+
+	// -0 == +0 so the sort leaves them mixed up, put the -0s first
+	lo, hi := LowerBound(vec, 0), UpperBound(vec, 0)
+	k := lo
+	for i := lo; i < hi; i++ {
+		if math.Signbit(vec[i]) {
+			vec[i], vec[k] = vec[k], vec[i]
+			k++
+		}
+	}
+	return nil
+}
+
+// Three-way comparison of floats that is a total order, unlike < on floats:
+//
+//	-Inf < ... < -0 < +0 < ... < +Inf < NaN
+//
+// Every NaN is equal to every other NaN. That is the same order
+// Float32RadixSort and SortFloatsChecked use, so pass it to MergeSortCmp or
+// any other comparator based sort to sort floats that might have NaNs in them
+func CompareFloat(a, b float64) int {
+	aNaN, bNaN := math.IsNaN(a), math.IsNaN(b)
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		return 1
+	case bNaN:
+		return -1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	// equal, but -0 and +0 are equal too
+	aNeg, bNeg := math.Signbit(a), math.Signbit(b)
+	switch {
+	case aNeg == bNeg:
+		return 0
+	case aNeg:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// a < b in the order of CompareFloat
+func LessTotal(a, b float64) bool {
+	return CompareFloat(a, b) < 0
+}
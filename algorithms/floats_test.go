@@ -0,0 +1,107 @@
+package algorithms
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSortFloatsChecked(t *testing.T) {
+	nan, inf, negZero := math.NaN(), math.Inf(1), math.Copysign(0, -1)
+
+	tests := []struct {
+		name    string
+		vec     []float64
+		want    []float64
+		wantErr error
+	}{
+		{"empty", nil, nil, nil},
+		{"no NaN", []float64{3, -inf, 1, inf, -2}, []float64{-inf, -2, 1, 3, inf}, nil},
+		{"zeros", []float64{0, negZero, 1, 0, negZero}, []float64{negZero, negZero, 0, 0, 1}, nil},
+		{"NaN", []float64{3, nan, 1}, nil, ErrNaN},
+		{"only NaN", []float64{nan}, nil, ErrNaN},
+		{"NaN last", []float64{3, 2, 1, nan}, nil, ErrNaN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			err := SortFloatsChecked(vec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SortFloatsChecked = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				// untouched: compare bits, NaN != NaN
+				for i := range vec {
+					if math.Float64bits(vec[i]) != math.Float64bits(tt.vec[i]) {
+						t.Fatalf("SortFloatsChecked touched vec before failing: %v", vec)
+					}
+				}
+				return
+			}
+			for i := range vec {
+				if vec[i] != tt.want[i] || math.Signbit(vec[i]) != math.Signbit(tt.want[i]) {
+					t.Fatalf("SortFloatsChecked = %v, want %v", vec, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareFloat(t *testing.T) {
+	nan, inf, negZero := math.NaN(), math.Inf(1), math.Copysign(0, -1)
+	want := []float64{-inf, negZero, 0, 1, inf, nan}
+	sameBits := func(got []float64) bool {
+		for i := range got {
+			// converting to float32 and back can change the NaN payload
+			if math.IsNaN(got[i]) && math.IsNaN(want[i]) {
+				continue
+			}
+			if math.Float64bits(got[i]) != math.Float64bits(want[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	vec := []float64{nan, 1, negZero, 0, inf, -inf}
+	MergeSortCmp(vec, CompareFloat)
+	if !sameBits(vec) {
+		t.Errorf("MergeSortCmp with CompareFloat = %v, want %v", vec, want)
+	}
+
+	vec32 := []float32{float32(nan), 1, float32(negZero), 0, float32(inf), float32(-inf)}
+	Float32RadixSort(vec32)
+	got := make([]float64, len(vec32))
+	for i, val := range vec32 {
+		got[i] = float64(val)
+	}
+	if !sameBits(got) {
+		t.Errorf("Float32RadixSort = %v, want %v", vec32, want)
+	}
+
+	// the order of want is strict, so every pair has to agree with it and
+	// CompareFloat has to be antisymmetric
+	for i := range want {
+		for j := range want {
+			wantCmp := 0
+			if i < j {
+				wantCmp = -1
+			} else if i > j {
+				wantCmp = 1
+			}
+			if got := CompareFloat(want[i], want[j]); got != wantCmp {
+				t.Errorf("CompareFloat(%v, %v) = %d, want %d", want[i], want[j], got, wantCmp)
+			}
+			if got := LessTotal(want[i], want[j]); got != (i < j) {
+				t.Errorf("LessTotal(%v, %v) = %v", want[i], want[j], got)
+			}
+		}
+	}
+
+	if CompareFloat(nan, -nan) != 0 {
+		t.Errorf("NaNs with different signs aren't equal")
+	}
+}
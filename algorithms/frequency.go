@@ -0,0 +1,48 @@
+package algorithms
+
+import "cmp"
+
+// A run of equal elements inside a sorted slice
+type run struct {
+	start int
+	count int
+}
+
+// Most frequent values first, ties broken by the smaller value first.
+// [1 1 2 3 3 3] becomes [3 3 3 1 1 2]
+func FrequencySort[T Ordered](vec []T) {
+	FrequencySortFunc(vec, cmp.Compare[T])
+}
+
+// Same as FrequencySort but elements are grouped by cmp(a, b) == 0. Inside
+// a group elements keep their original order.
+func FrequencySortFunc[T any](vec []T, cmp func(a, b T) int) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	// Sorting first puts equal elements next to each other, so counting
+	// is a single pass over the runs
+	MergeSortCmp(vec, cmp)
+
+	var runs []run
+	for i := 0; i < len(vec); i++ {
+		if i == 0 || cmp(vec[i], vec[i-1]) != 0 {
+			runs = append(runs, run{start: i})
+		}
+		runs[len(runs)-1].count++
+	}
+
+	// Runs are already in ascending value order and the merge sort is stable,
+	// so sorting by count alone gives the value tie-break for free
+	MergeSortCmp(runs, func(a, b run) int {
+		return b.count - a.count
+	})
+
+	output := make([]T, 0, len(vec))
+	for _, r := range runs {
+		output = append(output, vec[r.start:r.start+r.count]...)
+	}
+
+	copy(vec, output)
+}
@@ -0,0 +1,29 @@
+package algorithms
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFrequencySort(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []int
+		want []int
+	}{
+		{"doc example", []int{1, 1, 2, 3, 3, 3}, []int{3, 3, 3, 1, 1, 2}},
+		{"ties by value", []int{5, 2, 5, 2, 9}, []int{2, 2, 5, 5, 9}},
+		{"all unique", []int{3, 1, 2}, []int{1, 2, 3}},
+		{"negatives", []int{-1, 4, -1, 4, 4}, []int{4, 4, 4, -1, -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			FrequencySort(vec)
+			if !slices.Equal(vec, tt.want) {
+				t.Errorf("FrequencySort(%v) = %v, want %v", tt.vec, vec, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package algorithms
+
+// Once one side of a merge wins this many comparisons in a row the merge
+// switches to galloping, same number TimSort uses
+const MinGallop = 7
+
+// merge, but when one run keeps winning it stops comparing one element at a
+// time. It searches for how far that run keeps winning (exponential search,
+// then binary search) and copies that whole block at once. On runs that
+// barely interleave that is O(log n) comparisons instead of O(n). Ties still
+// go to the left run, so it is stable
+func gallopMerge[T Ordered](vec []T, tmp []T, start int, mid int, end int) {
+	// runs are already in order, nothing to merge
+	if vec[mid] <= vec[mid+1] {
+		return
+	}
+
+	i, j, k := start, mid+1, start
+	leftWins, rightWins := 0, 0
+
+	for i <= mid && j <= end {
+		if vec[i] <= vec[j] {
+			tmp[k] = vec[i]
+			i++
+			leftWins++
+			rightWins = 0
+		} else {
+			tmp[k] = vec[j]
+			j++
+			rightWins++
+			leftWins = 0
+		}
+		k++
+
+		if i > mid || j > end {
+			break
+		}
+
+		if leftWins >= MinGallop {
+			// every left element <= vec[j] goes before it
+			key := vec[j]
+			n := gallop(mid+1-i, func(x int) bool { return vec[i+x] > key })
+			k += copy(tmp[k:], vec[i:i+n])
+			i += n
+			leftWins = 0
+		} else if rightWins >= MinGallop {
+			// every right element < vec[i] goes before it
+			key := vec[i]
+			n := gallop(end+1-j, func(x int) bool { return vec[j+x] >= key })
+			k += copy(tmp[k:], vec[j:j+n])
+			j += n
+			rightWins = 0
+		}
+	}
+
+	k += copy(tmp[k:], vec[i:mid+1])
+	copy(tmp[k:], vec[j:end+1])
+	copy(vec[start:end+1], tmp[start:end+1])
+}
+
+// First x in [0, n] where pred(x) is true, pred has to be false and then
+// true. Checks 0, 1, 3, 7, ... first and then binary searches the last gap,
+// so it is O(log x) instead of O(log n) when the answer is near the start
+func gallop(n int, pred func(x int) bool) int {
+	bound := 1
+	for bound <= n && !pred(bound-1) {
+		bound *= 2
+	}
+
+	// pred(bound/2 - 1) was false, so the answer is at least bound/2
+	lo, hi := bound/2, min(bound-1, n)
+	for lo < hi {
+		m := lo + (hi-lo)/2
+		if pred(m) {
+			hi = m
+		} else {
+			lo = m + 1
+		}
+	}
+	return lo
+}
@@ -0,0 +1,98 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestGallop(t *testing.T) {
+	for n := 0; n <= 100; n++ {
+		for answer := 0; answer <= n; answer++ {
+			if got := gallop(n, func(x int) bool { return x >= answer }); got != answer {
+				t.Fatalf("gallop(%d) = %d, want %d", n, got, answer)
+			}
+		}
+	}
+}
+
+// Two runs that take turns winning in blocks of every size, so the merge
+// switches in and out of galloping. Zeros of both signs are spread through
+// both runs to check ties still go to the left one
+func TestGallopMergeStable(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	negZero := math.Copysign(0, -1)
+
+	for i := 0; i < 2000; i++ {
+		left, right := rng.Intn(60)+1, rng.Intn(60)+1
+		vec := make([]float64, left+right)
+		for j := range vec {
+			switch rng.Intn(5) {
+			case 0:
+				vec[j] = negZero
+			case 1:
+				vec[j] = 0
+			default:
+				// big blocks of one value make long winning streaks
+				vec[j] = float64(rng.Intn(4)*10 + rng.Intn(2))
+			}
+		}
+		InsertionSort(vec[:left])
+		InsertionSort(vec[left:])
+		want := zeroSigns(vec)
+		in := slices.Clone(vec)
+
+		gallopMerge(vec, make([]float64, len(vec)), 0, left-1, len(vec)-1)
+		if !slices.IsSorted(vec) || !slices.Equal(zeroSigns(vec), want) {
+			t.Fatalf("gallopMerge(%v, mid=%d) = %v, not a stable merge", in, left-1, vec)
+		}
+	}
+}
+
+// One run's elements all fall in a small range of the other's, so one side
+// wins almost every comparison
+func dominantRuns(n int) []int {
+	vec := make([]int, n)
+	half := n / 2
+	for i := 0; i < half; i++ {
+		vec[i] = 2 * i
+	}
+	for i := half; i < n; i++ {
+		vec[i] = half + 2*(i-half)%100
+	}
+	InsertionSort(vec[half:])
+	return vec
+}
+
+func BenchmarkGallopMerge(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		merge func(vec, tmp []int, start, mid, end int)
+	}{
+		{"gallopMerge", gallopMerge[int]},
+		{"merge", merge[int]},
+	}
+	inputs := []struct {
+		name string
+		vec  []int
+	}{
+		{"dominant run", dominantRuns(1 << 16)},
+		{"interleaved", append(slices.Sorted(slices.Values(RandomInts(1<<15, rand.New(rand.NewSource(BenchmarkSeed))))),
+			slices.Sorted(slices.Values(RandomInts(1<<15, rand.New(rand.NewSource(BenchmarkSeed+1)))))...)},
+	}
+
+	for _, input := range inputs {
+		for _, bm := range benchmarks {
+			b.Run(bm.name+"/"+input.name, func(b *testing.B) {
+				vec := make([]int, len(input.vec))
+				tmp := make([]int, len(input.vec))
+				mid := len(vec)/2 - 1
+				for i := 0; i < b.N; i++ {
+					copy(vec, input.vec)
+					bm.merge(vec, tmp, 0, mid, len(vec)-1)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package algorithms
+
+import "fmt"
+
+// Heap primitives that HeapSort is built on. The heap lives in the slice
+// itself: the root is at index 0 and the children of index i are at 2i+1 and
+// 2i+2, so the parent of i is at (i-1)/2.
+
+// Sifts vec[i] down until it is >= both of its children, only looking at
+// vec[:n]. Assumes the subtrees below i are max-heaps already
+func Heapify[T Ordered](vec []T, i, n int) {
+	heapify(vec, i, n)
+}
+
+// Rearranges vec so every element is >= its children, vec[0] is the max
+func BuildMaxHeap[T Ordered](vec []T) {
+	buildHeap(vec)
+}
+
+// Same as Heapify but for a min-heap, vec[i] sifts down until it is <= both
+// of its children
+func MinHeapify[T Ordered](vec []T, i, n int) {
+	for {
+		smallest := i
+		left := 2*i + 1
+		right := 2*i + 2
+
+		if left < n && vec[left] < vec[smallest] {
+			smallest = left
+		}
+
+		if right < n && vec[right] < vec[smallest] {
+			smallest = right
+		}
+
+		if smallest == i {
+			return
+		}
+
+		vec[i], vec[smallest] = vec[smallest], vec[i]
+		i = smallest
+	}
+}
+
+// Rearranges vec so every element is <= its children, vec[0] is the min
+func BuildMinHeap[T Ordered](vec []T) {
+	n := len(vec)
+	for i := n/2 - 1; i >= 0; i-- {
+		MinHeapify(vec, i, n)
+	}
+}
+
+// HeapSort with a min-heap, so the smallest elements end up at the back and
+// vec is sorted in descending order
+func HeapSortDesc[T Ordered](vec []T) {
+	n := len(vec)
+	BuildMinHeap(vec)
+	for i := n - 1; i > 0; i-- {
+		vec[0], vec[i] = vec[i], vec[0]
+		MinHeapify(vec, 0, i)
+	}
+}
+
+// HeapSort on a d-ary heap, the children of index i are at d*i+1 .. d*i+d and
+// the parent of i is at (i-1)/d. A wider heap is shallower and the children
+// of a node sit next to each other in memory, so sifting down touches fewer
+// cache lines. d=4 is usually a good choice, d=2 is the same as HeapSort.
+// Panics if d < 2
+func DaryHeapSort[T Ordered](vec []T, d int) {
+	if d < 2 {
+		panic(fmt.Sprintf("algorithms: DaryHeapSort needs d >= 2, got %d", d))
+	}
+
+	n := len(vec)
+	for i := (n - 2) / d; i >= 0 && n > 1; i-- {
+		daryHeapify(vec, i, n, d)
+	}
+
+	for i := n - 1; i > 0; i-- {
+		vec[0], vec[i] = vec[i], vec[0]
+		daryHeapify(vec, 0, i, d)
+	}
+}
+
+// Same as heapify but every node has up to d children
+func daryHeapify[T Ordered](vec []T, i, n, d int) {
+	for {
+		largest := i
+		first := d*i + 1
+		last := min(first+d, n)
+
+		for child := first; child < last; child++ {
+			if vec[child] > vec[largest] {
+				largest = child
+			}
+		}
+
+		if largest == i {
+			return
+		}
+
+		vec[i], vec[largest] = vec[largest], vec[i]
+		i = largest
+	}
+}
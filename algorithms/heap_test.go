@@ -0,0 +1,159 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Checks every parent against both of its children in vec[:n]
+func isHeap(vec []int, n int, ok func(parent, child int) bool) bool {
+	for i := 1; i < n; i++ {
+		if !ok(vec[(i-1)/2], vec[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBuildHeaps(t *testing.T) {
+	maxOK := func(parent, child int) bool { return parent >= child }
+	minOK := func(parent, child int) bool { return parent <= child }
+
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 7, 8, 100, 1001} {
+		for _, in := range [][]int{RandomInts(n, rng), SortedInts(n), ReverseSortedInts(n), FewUniqueInts(n, rng)} {
+			vec := slices.Clone(in)
+			BuildMaxHeap(vec)
+			if !isHeap(vec, n, maxOK) {
+				t.Fatalf("BuildMaxHeap(%v) = %v", in, vec)
+			}
+
+			vec = slices.Clone(in)
+			BuildMinHeap(vec)
+			if !isHeap(vec, n, minOK) {
+				t.Fatalf("BuildMinHeap(%v) = %v", in, vec)
+			}
+		}
+	}
+}
+
+// Heapify only looks at vec[:n], and is all it takes to put a new root in
+// place, which is how HeapSort pops the max
+func TestHeapify(t *testing.T) {
+	maxOK := func(parent, child int) bool { return parent >= child }
+	vec := RandomInts(500, rand.New(rand.NewSource(1)))
+	BuildMaxHeap(vec)
+
+	for n := len(vec) - 1; n > 0; n-- {
+		vec[0], vec[n] = vec[n], vec[0]
+		Heapify(vec, 0, n)
+		if !isHeap(vec, n, maxOK) {
+			t.Fatalf("not a max-heap after Heapify with n=%d", n)
+		}
+		if vec[n] < vec[0] {
+			t.Fatalf("Heapify moved something past n=%d", n)
+		}
+	}
+
+	minVec := RandomInts(500, rand.New(rand.NewSource(2)))
+	BuildMinHeap(minVec)
+	minVec[0] = 1 << 30
+	MinHeapify(minVec, 0, len(minVec))
+	if !isHeap(minVec, len(minVec), func(parent, child int) bool { return parent <= child }) {
+		t.Errorf("not a min-heap after MinHeapify")
+	}
+}
+
+func TestHeapSortDesc(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name string
+		vec  []int
+	}{
+		{"empty", nil},
+		{"single", []int{5}},
+		{"duplicates", []int{3, 1, 3, 2, 1, 3}},
+		{"all equal", []int{4, 4, 4, 4}},
+		{"sorted", SortedInts(100)},
+		{"random", RandomInts(1000, rng)},
+		{"few unique", FewUniqueInts(1000, rng)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			HeapSortDesc(vec)
+
+			want := slices.Sorted(slices.Values(tt.vec))
+			slices.Reverse(want)
+			if !slices.Equal(vec, want) {
+				t.Errorf("HeapSortDesc(%v) = %v, want %v", tt.vec, vec, want)
+			}
+		})
+	}
+}
+
+func TestDaryHeapSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, d := range []int{2, 3, 4, 5, 8, 16} {
+		for _, n := range []int{0, 1, 2, d, d + 1, d*d + 1, 100, 1001} {
+			for _, in := range [][]int{RandomInts(n, rng), SortedInts(n), ReverseSortedInts(n), FewUniqueInts(n, rng)} {
+				vec := slices.Clone(in)
+				DaryHeapSort(vec, d)
+				if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+					t.Fatalf("DaryHeapSort(%v, %d) = %v", in, d, vec)
+				}
+			}
+		}
+	}
+}
+
+func TestDaryHeapSortPanics(t *testing.T) {
+	for _, d := range []int{1, 0, -2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("DaryHeapSort(d=%d) didn't panic", d)
+				}
+			}()
+			DaryHeapSort([]int{2, 1}, d)
+		}()
+	}
+}
+
+func BenchmarkDaryHeapSort(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"HeapSort", HeapSort[int]},
+		{"d=2", func(v []int) { DaryHeapSort(v, 2) }},
+		{"d=4", func(v []int) { DaryHeapSort(v, 4) }},
+		{"d=8", func(v []int) { DaryHeapSort(v, 8) }},
+	}
+	sizes := []struct {
+		name string
+		n    int
+	}{
+		{"64K", 1 << 16},
+		{"4M", 1 << 22},
+	}
+
+	for _, size := range sizes {
+		var input []int
+		for _, bm := range benchmarks {
+			b.Run(bm.name+"/"+size.name, func(b *testing.B) {
+				if input == nil {
+					input = RandomInts(size.n, rand.New(rand.NewSource(BenchmarkSeed)))
+				}
+				vec := make([]int, len(input))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					copy(vec, input)
+					bm.sort(vec)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package algorithms
+
+import "math/rand"
+
+// Fisher-Yates shuffle. Pass a seeded rng to get the same permutation
+// every time, or nil to use the default source.
+func Shuffle[T any](vec []T, rng *rand.Rand) {
+	intn := rand.Intn
+	if rng != nil {
+		intn = rng.Intn
+	}
+
+	for i := len(vec) - 1; i > 0; i-- {
+		// i+1 is important here, j must be able to be i itself
+		// otherwise the shuffle is biased
+		j := intn(i + 1)
+		vec[i], vec[j] = vec[j], vec[i]
+	}
+}
+
+// Reverses vec in place
+func Reverse[T any](vec []T) {
+	for i, j := 0, len(vec)-1; i < j; i, j = i+1, j-1 {
+		vec[i], vec[j] = vec[j], vec[i]
+	}
+}
+
+// Returns a reversed copy, vec itself is left untouched
+func Reversed[T any](vec []T) []T {
+	out := make([]T, len(vec))
+	for i, val := range vec {
+		out[len(vec)-1-i] = val
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestShuffle(t *testing.T) {
+	in := SortedInts(100)
+
+	a := slices.Clone(in)
+	Shuffle(a, rand.New(rand.NewSource(1)))
+	b := slices.Clone(in)
+	Shuffle(b, rand.New(rand.NewSource(1)))
+
+	if !slices.Equal(a, b) {
+		t.Errorf("two shuffles with the same seed differ")
+	}
+	if slices.Equal(a, in) {
+		t.Errorf("Shuffle left 100 elements in order")
+	}
+	if !slices.Equal(slices.Sorted(slices.Values(a)), in) {
+		t.Errorf("Shuffle didn't return a permutation: %v", a)
+	}
+
+	c := slices.Clone(in)
+	Shuffle(c, rand.New(rand.NewSource(2)))
+	if slices.Equal(a, c) {
+		t.Errorf("different seeds gave the same shuffle")
+	}
+
+	// nil rng uses the default source, still a permutation
+	d := []int{3, 3, 1, 2}
+	Shuffle(d, nil)
+	if !slices.Equal(slices.Sorted(slices.Values(d)), []int{1, 2, 3, 3}) {
+		t.Errorf("Shuffle with a nil rng gave %v", d)
+	}
+
+	Shuffle([]int(nil), nil)
+	Shuffle([]int{1}, rand.New(rand.NewSource(1)))
+}
+
+// Every position of a 3 element slice should be equally likely to get every
+// element, a biased j (e.g. rng.Intn(i)) shows up right away
+func TestShuffleUniform(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const runs = 30_000
+	var counts [3][3]int
+	for i := 0; i < runs; i++ {
+		vec := []int{0, 1, 2}
+		Shuffle(vec, rng)
+		for pos, val := range vec {
+			counts[pos][val]++
+		}
+	}
+
+	for pos := range counts {
+		for val, c := range counts[pos] {
+			if c < runs/3*9/10 || c > runs/3*11/10 {
+				t.Errorf("element %d landed at %d in %d of %d shuffles", val, pos, c, runs)
+			}
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package algorithms
+
+// Counts how much work a sort did. Comparisons is the number of times two
+// elements were compared, Swaps is the number of times two elements were
+// swapped. Merge sort only swaps in its insertion sorted leaves, so for it
+// Swaps also counts the elements written back into vec while merging.
+type Stats struct {
+	Comparisons int
+	Swaps       int
+}
+
+// The instrumented versions below are copies of the plain ones with counters
+// added, down to the cutoffs, leaf sorts and galloping, so they do exactly
+// the same comparisons and moves. They are kept separate on purpose so the
+// plain sorts don't pay for any of the bookkeeping. A change to a plain sort
+// has to be made here too, TestInstrumentedMatchesPlain catches it if not.
+
+func swapCounted[T any](vec []T, i, j int, stats *Stats) {
+	vec[i], vec[j] = vec[j], vec[i]
+	stats.Swaps++
+}
+
+func SimpleSortInstrumented[T Ordered](vec []T) Stats {
+	var stats Stats
+	for i := 0; i < len(vec)-1; i++ {
+		for j := i + 1; j < len(vec); j++ {
+			stats.Comparisons++
+			if vec[j] < vec[i] {
+				swapCounted(vec, i, j, &stats)
+			}
+		}
+	}
+	return stats
+}
+
+func SelectionSortInstrumented[T Ordered](vec []T) Stats {
+	var stats Stats
+	for i := 0; i < len(vec)-1; i++ {
+		minIndex := i
+		for j := i + 1; j < len(vec); j++ {
+			stats.Comparisons++
+			if vec[j] < vec[minIndex] {
+				minIndex = j
+			}
+		}
+		if minIndex != i {
+			swapCounted(vec, i, minIndex, &stats)
+		}
+	}
+	return stats
+}
+
+// Every swap fixes exactly one inversion, so Swaps is the number of
+// inversions in the input. For a reversed slice that is n*(n-1)/2.
+func BubbleSortInstrumented[T Ordered](vec []T) Stats {
+	var stats Stats
+	for i := 0; i < len(vec)-1; i++ {
+		swapped := false
+		for j := 0; j < len(vec)-1-i; j++ {
+			stats.Comparisons++
+			if vec[j] > vec[j+1] {
+				swapCounted(vec, j, j+1, &stats)
+				swapped = true
+			}
+		}
+
+		if !swapped {
+			break
+		}
+	}
+	return stats
+}
+
+func InsertionSortInstrumented[T Ordered](vec []T) Stats {
+	var stats Stats
+	insertionSortCounted(vec, &stats)
+	return stats
+}
+
+func insertionSortCounted[T Ordered](vec []T, stats *Stats) {
+	for i := 1; i < len(vec); i++ {
+		for j := i; j > 0; j-- {
+			stats.Comparisons++
+			if vec[j] >= vec[j-1] {
+				break
+			}
+			swapCounted(vec, j, j-1, stats)
+		}
+	}
+}
+
+func MergeSortInstrumented[T Ordered](vec []T) Stats {
+	var stats Stats
+	if len(vec) <= 1 {
+		return stats
+	}
+
+	tmp := make([]T, len(vec))
+	mergeSortInstrumentedHelper(vec, tmp, 0, len(vec)-1, &stats)
+	return stats
+}
+
+func mergeSortInstrumentedHelper[T Ordered](vec []T, tmp []T, start int, end int, stats *Stats) {
+	if end-start+1 <= InsertionSortCutoff {
+		insertionSortCounted(vec[start:end+1], stats)
+		return
+	}
+
+	mid := start + (end-start)/2
+	mergeSortInstrumentedHelper(vec, tmp, start, mid, stats)
+	mergeSortInstrumentedHelper(vec, tmp, mid+1, end, stats)
+	gallopMergeInstrumented(vec, tmp, start, mid, end, stats)
+}
+
+func gallopMergeInstrumented[T Ordered](vec []T, tmp []T, start int, mid int, end int, stats *Stats) {
+	stats.Comparisons++
+	if vec[mid] <= vec[mid+1] {
+		return
+	}
+
+	i, j, k := start, mid+1, start
+	leftWins, rightWins := 0, 0
+
+	for i <= mid && j <= end {
+		stats.Comparisons++
+		if vec[i] <= vec[j] {
+			tmp[k] = vec[i]
+			i++
+			leftWins++
+			rightWins = 0
+		} else {
+			tmp[k] = vec[j]
+			j++
+			rightWins++
+			leftWins = 0
+		}
+		k++
+
+		if i > mid || j > end {
+			break
+		}
+
+		if leftWins >= MinGallop {
+			key := vec[j]
+			n := gallop(mid+1-i, func(x int) bool {
+				stats.Comparisons++
+				return vec[i+x] > key
+			})
+			k += copy(tmp[k:], vec[i:i+n])
+			i += n
+			leftWins = 0
+		} else if rightWins >= MinGallop {
+			key := vec[i]
+			n := gallop(end+1-j, func(x int) bool {
+				stats.Comparisons++
+				return vec[j+x] >= key
+			})
+			k += copy(tmp[k:], vec[j:j+n])
+			j += n
+			rightWins = 0
+		}
+	}
+
+	k += copy(tmp[k:], vec[i:mid+1])
+	copy(tmp[k:], vec[j:end+1])
+	copy(vec[start:end+1], tmp[start:end+1])
+	stats.Swaps += end - start + 1
+}
+
+func QuickSortInstrumented[T Ordered](vec []T) Stats {
+	var stats Stats
+	if len(vec) <= 1 {
+		return stats
+	}
+
+	quickSortInstrumentedHelper(vec, 0, len(vec)-1, &stats)
+	return stats
+}
+
+func quickSortInstrumentedHelper[T Ordered](vec []T, start int, end int, stats *Stats) {
+	for end-start+1 > InsertionSortCutoff {
+		pivot := partitionInstrumented(vec, start, end, stats)
+		if pivot-start < end-pivot {
+			quickSortInstrumentedHelper(vec, start, pivot-1, stats)
+			start = pivot + 1
+		} else {
+			quickSortInstrumentedHelper(vec, pivot+1, end, stats)
+			end = pivot - 1
+		}
+	}
+
+	smallSortInstrumented(vec[start:end+1], stats)
+}
+
+func smallSortInstrumented[T Ordered](vec []T, stats *Stats) {
+	if len(vec) > MaxNetworkSize {
+		insertionSortCounted(vec, stats)
+		return
+	}
+
+	for _, pair := range sortingNetworks[len(vec)] {
+		i, j := pair[0], pair[1]
+		stats.Comparisons++
+		if vec[j] < vec[i] {
+			swapCounted(vec, i, j, stats)
+		}
+	}
+}
+
+func partitionInstrumented[T Ordered](vec []T, start int, end int, stats *Stats) int {
+	mid := start + (end-start)/2
+	pivotIndex := medianOfThreeInstrumented(vec, start, mid, end, stats)
+	swapCounted(vec, pivotIndex, end, stats)
+
+	pivot := vec[end]
+	i := start - 1
+
+	for j := start; j < end; j++ {
+		stats.Comparisons++
+		if vec[j] <= pivot {
+			i++
+			swapCounted(vec, i, j, stats)
+		}
+	}
+
+	swapCounted(vec, i+1, end, stats)
+	return i + 1
+}
+
+func medianOfThreeInstrumented[T Ordered](vec []T, i, j, k int, stats *Stats) int {
+	stats.Comparisons += 2
+	if (vec[i] > vec[j]) != (vec[i] > vec[k]) {
+		return i
+	}
+	stats.Comparisons += 2
+	if (vec[j] > vec[i]) != (vec[j] > vec[k]) {
+		return j
+	}
+	return k
+}
+
+func HeapSortInstrumented[T Ordered](vec []T) Stats {
+	var stats Stats
+	n := len(vec)
+	for i := n/2 - 1; i >= 0; i-- {
+		heapifyInstrumented(vec, i, n, &stats)
+	}
+	// i > 0, the last swap would be vec[0] with itself
+	for i := n - 1; i > 0; i-- {
+		swapCounted(vec, 0, i, &stats)
+		heapifyInstrumented(vec, 0, i, &stats)
+	}
+	return stats
+}
+
+func heapifyInstrumented[T Ordered](vec []T, i int, n int, stats *Stats) {
+	largest := i
+	left := 2*i + 1
+	right := 2*i + 2
+
+	if left < n {
+		stats.Comparisons++
+		if vec[left] > vec[largest] {
+			largest = left
+		}
+	}
+
+	if right < n {
+		stats.Comparisons++
+		if vec[right] > vec[largest] {
+			largest = right
+		}
+	}
+
+	if largest != i {
+		swapCounted(vec, i, largest, stats)
+		heapifyInstrumented(vec, largest, n, stats)
+	}
+}
@@ -0,0 +1,150 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestBubbleSortInstrumentedReversed(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 100, 1000} {
+		stats := BubbleSortInstrumented(ReverseSortedInts(n))
+		// every pair is an inversion, and no pass ends early
+		want := n * (n - 1) / 2
+		if stats.Swaps != want || stats.Comparisons != want {
+			t.Errorf("n=%d: %+v, want %d swaps and comparisons", n, stats, want)
+		}
+	}
+}
+
+// Bubble and insertion sort only ever swap neighbours that are out of order,
+// so both do exactly as many swaps as there are inversions
+func TestInstrumentedSwapsAreInversions(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 5, 100, 1000} {
+		for _, in := range [][]int{RandomInts(n, rng), FewUniqueInts(n, rng), SortedInts(n)} {
+			want := int(CountInversions(slices.Clone(in)))
+			if got := BubbleSortInstrumented(slices.Clone(in)).Swaps; got != want {
+				t.Errorf("n=%d: BubbleSortInstrumented did %d swaps, want %d", n, got, want)
+			}
+			if got := InsertionSortInstrumented(slices.Clone(in)).Swaps; got != want {
+				t.Errorf("n=%d: InsertionSortInstrumented did %d swaps, want %d", n, got, want)
+			}
+		}
+	}
+}
+
+func TestInstrumentedSorts(t *testing.T) {
+	sorts := []struct {
+		name string
+		sort func([]int) Stats
+	}{
+		{"SimpleSortInstrumented", SimpleSortInstrumented[int]},
+		{"SelectionSortInstrumented", SelectionSortInstrumented[int]},
+		{"BubbleSortInstrumented", BubbleSortInstrumented[int]},
+		{"InsertionSortInstrumented", InsertionSortInstrumented[int]},
+		{"MergeSortInstrumented", MergeSortInstrumented[int]},
+		{"QuickSortInstrumented", QuickSortInstrumented[int]},
+		{"HeapSortInstrumented", HeapSortInstrumented[int]},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	inputs := map[string][]int{
+		"empty":      {},
+		"single":     {1},
+		"random":     RandomInts(500, rng),
+		"sorted":     SortedInts(500),
+		"reverse":    ReverseSortedInts(500),
+		"few unique": FewUniqueInts(500, rng),
+	}
+
+	for _, s := range sorts {
+		for name, in := range inputs {
+			vec := slices.Clone(in)
+			stats := s.sort(vec)
+			if !slices.IsSorted(vec) {
+				t.Errorf("%s didn't sort %s input", s.name, name)
+			}
+			if len(in) <= 1 && stats != (Stats{}) {
+				t.Errorf("%s on %s input reported %+v", s.name, name, stats)
+			}
+			if len(in) > 1 && stats.Comparisons < len(in)-1 {
+				t.Errorf("%s on %s input did %d comparisons, can't sort with fewer than %d", s.name, name, stats.Comparisons, len(in)-1)
+			}
+		}
+	}
+}
+
+func TestInstrumentedExactCounts(t *testing.T) {
+	tests := []struct {
+		name string
+		sort func([]int) Stats
+		vec  []int
+		want Stats
+	}{
+		// one pass that finds nothing to swap
+		{"bubble sorted", BubbleSortInstrumented[int], SortedInts(100), Stats{99, 0}},
+		{"insertion sorted", InsertionSortInstrumented[int], SortedInts(100), Stats{99, 0}},
+		{"selection reverse", SelectionSortInstrumented[int], ReverseSortedInts(100), Stats{4950, 50}},
+		// 128 leaves of 8 compare their 7 neighbours, and each of the 127
+		// merges stops after one comparison since its runs are in order
+		{"merge sorted", MergeSortInstrumented[int], SortedInts(1024), Stats{128*7 + 127, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.sort(tt.vec)
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// The instrumented sorts have to do exactly what the plain ones do, or the
+// counts in RunBenchmark describe a different algorithm than the one timed.
+// -0 and +0 compare equal but can be told apart, so where each sign of zero
+// ends up shows every swap the unstable sorts made
+func TestInstrumentedMatchesPlain(t *testing.T) {
+	pairs := []struct {
+		name         string
+		sort         func([]float64)
+		instrumented func([]float64) Stats
+	}{
+		{"SimpleSort", SimpleSort[float64], SimpleSortInstrumented[float64]},
+		{"SelectionSort", SelectionSort[float64], SelectionSortInstrumented[float64]},
+		{"BubbleSort", BubbleSort[float64], BubbleSortInstrumented[float64]},
+		{"InsertionSort", InsertionSort[float64], InsertionSortInstrumented[float64]},
+		{"MergeSort", MergeSort[float64], MergeSortInstrumented[float64]},
+		{"QuickSort", QuickSort[float64], QuickSortInstrumented[float64]},
+		{"HeapSort", HeapSort[float64], HeapSortInstrumented[float64]},
+	}
+
+	negZero := math.Copysign(0, -1)
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{2, 5, 8, 9, InsertionSortCutoff, InsertionSortCutoff + 1, 100, 1000} {
+		in := make([]float64, n)
+		for i := range in {
+			switch rng.Intn(3) {
+			case 0:
+				in[i] = negZero
+			case 1:
+				in[i] = 0
+			default:
+				in[i] = float64(rng.Intn(n) - n/2)
+			}
+		}
+
+		for _, p := range pairs {
+			plain, counted := slices.Clone(in), slices.Clone(in)
+			p.sort(plain)
+			p.instrumented(counted)
+			for i := range plain {
+				if math.Float64bits(plain[i]) != math.Float64bits(counted[i]) {
+					t.Fatalf("%s n=%d: the instrumented version moved elements differently at %d", p.name, n, i)
+				}
+			}
+		}
+	}
+}
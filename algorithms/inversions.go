@@ -0,0 +1,51 @@
+package algorithms
+
+import "slices"
+
+// Number of pairs i < j with vec[i] > vec[j], in O(n log n). 0 means sorted,
+// n*(n-1)/2 means reverse sorted. vec itself isn't touched, a copy is sorted
+func CountInversions[T Ordered](vec []T) int64 {
+	if len(vec) <= 1 {
+		return 0
+	}
+
+	work := slices.Clone(vec)
+	tmp := make([]T, len(vec))
+	return countInversionsHelper(work, tmp, 0, len(work)-1)
+}
+
+func countInversionsHelper[T Ordered](vec []T, tmp []T, start int, end int) int64 {
+	if start >= end {
+		return 0
+	}
+
+	mid := start + (end-start)/2
+	count := countInversionsHelper(vec, tmp, start, mid)
+	count += countInversionsHelper(vec, tmp, mid+1, end)
+	return count + mergeCountInversions(vec, tmp, start, mid, end)
+}
+
+// merge, but every time the right side wins it jumps over everything still
+// left on the left side, and each of those is one inversion
+func mergeCountInversions[T Ordered](vec []T, tmp []T, start int, mid int, end int) int64 {
+	var count int64
+	i, j, k := start, mid+1, start
+
+	for i <= mid && j <= end {
+		if vec[i] <= vec[j] {
+			tmp[k] = vec[i]
+			i++
+		} else {
+			tmp[k] = vec[j]
+			j++
+			count += int64(mid - i + 1)
+		}
+		k++
+	}
+
+	k += copy(tmp[k:], vec[i:mid+1])
+	copy(tmp[k:], vec[j:end+1])
+	copy(vec[start:end+1], tmp[start:end+1])
+
+	return count
+}
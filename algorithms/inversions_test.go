@@ -0,0 +1,57 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// O(n^2) pair count to check against
+func bruteForceInversions(vec []int) int64 {
+	var count int64
+	for i := range vec {
+		for j := i + 1; j < len(vec); j++ {
+			if vec[i] > vec[j] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestCountInversions(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []int
+		want int64
+	}{
+		{"empty", nil, 0},
+		{"single", []int{1}, 0},
+		{"sorted", SortedInts(100), 0},
+		{"reverse 2", ReverseSortedInts(2), 1},
+		{"reverse 100", ReverseSortedInts(100), 100 * 99 / 2},
+		{"reverse 10000", ReverseSortedInts(10_000), 10_000 * 9_999 / 2},
+		{"one swap", []int{1, 3, 2, 4}, 1},
+		{"equal aren't inversions", []int{2, 2, 2, 1}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := slices.Clone(tt.vec)
+			if got := CountInversions(in); got != tt.want {
+				t.Errorf("CountInversions = %d, want %d", got, tt.want)
+			}
+			if !slices.Equal(in, tt.vec) {
+				t.Errorf("CountInversions changed its input")
+			}
+		})
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		vec := FewUniqueInts(rng.Intn(300), rng)
+		if got, want := CountInversions(vec), bruteForceInversions(vec); got != want {
+			t.Fatalf("CountInversions(%v) = %d, want %d", vec, got, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package algorithms
+
+// One element in the k-way merge heap, src is the input it came from
+type mergeItem[T Ordered] struct {
+	val T
+	src int
+}
+
+// Merges k sorted inputs. next(src) returns the next element of input src
+// and false once that input is done. Every element is handed to emit in
+// order. Only one element per input is kept around, so it works on streams
+// of any size. Ties go to the input with the smaller index, so it is stable.
+func kWayMerge[T Ordered](k int, next func(src int) (T, bool, error), emit func(T) error) error {
+	items := make([]mergeItem[T], 0, k)
+	for src := 0; src < k; src++ {
+		val, ok, err := next(src)
+		if err != nil {
+			return err
+		}
+		if ok {
+			items = append(items, mergeItem[T]{val, src})
+		}
+	}
+
+	for i := len(items)/2 - 1; i >= 0; i-- {
+		mergeSiftDown(items, i)
+	}
+
+	for len(items) > 0 {
+		top := items[0]
+		if err := emit(top.val); err != nil {
+			return err
+		}
+
+		val, ok, err := next(top.src)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			items[0].val = val
+		} else {
+			items[0] = items[len(items)-1]
+			items = items[:len(items)-1]
+		}
+		mergeSiftDown(items, 0)
+	}
+
+	return nil
+}
+
+func mergeItemLess[T Ordered](a, b mergeItem[T]) bool {
+	return a.val < b.val || (a.val == b.val && a.src < b.src)
+}
+
+// Same as heapify but for a min-heap of mergeItems
+func mergeSiftDown[T Ordered](items []mergeItem[T], i int) {
+	n := len(items)
+	for {
+		smallest := i
+		left := 2*i + 1
+		right := 2*i + 2
+
+		if left < n && mergeItemLess(items[left], items[smallest]) {
+			smallest = left
+		}
+
+		if right < n && mergeItemLess(items[right], items[smallest]) {
+			smallest = right
+		}
+
+		if smallest == i {
+			return
+		}
+
+		items[i], items[smallest] = items[smallest], items[i]
+		i = smallest
+	}
+}
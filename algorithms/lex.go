@@ -0,0 +1,32 @@
+package algorithms
+
+import (
+	"bytes"
+	"slices"
+)
+
+// Sorts rows lexicographically: element by element, and if one row is a
+// prefix of the other, the shorter one goes first. So empty rows come first
+// and [[1 2] [1] [1 2 0]] becomes [[1] [1 2] [1 2 0]]. Stable. Only the
+// order of the rows changes, the rows themselves aren't touched
+func SortLex[T Ordered](vec [][]T) {
+	MergeSortCmp(vec, slices.Compare[[]T])
+}
+
+// Sorts 16 byte keys like UUIDs or MD5 sums byte by byte, without turning
+// them into slices first. Stable
+func SortByteArrays16(vec [][16]byte) {
+	MergeSortCmp(vec, func(a, b [16]byte) int {
+		return bytes.Compare(a[:], b[:])
+	})
+}
+
+// Sorts anything that can be viewed as bytes, compared like bytes.Compare.
+// Generics can't range over array lengths, so for a [N]byte of any other size
+// pass func(a [N]byte) []byte { return a[:] } as key. key is called on every
+// comparison, it shouldn't allocate. Stable
+func SortBytesKey[T any](vec []T, key func(T) []byte) {
+	MergeSortCmp(vec, func(a, b T) int {
+		return bytes.Compare(key(a), key(b))
+	})
+}
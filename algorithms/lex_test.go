@@ -0,0 +1,86 @@
+package algorithms
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSortLex(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  [][]int
+		want [][]int
+	}{
+		{"example", [][]int{{1, 2}, {1}, {1, 2, 0}}, [][]int{{1}, {1, 2}, {1, 2, 0}}},
+		{"empty rows first", [][]int{{0}, {}, {-1}, nil}, [][]int{{}, nil, {-1}, {0}}},
+		{"first difference wins", [][]int{{2}, {1, 9, 9}, {1, 10}}, [][]int{{1, 9, 9}, {1, 10}, {2}}},
+		{"equal rows", [][]int{{3, 1}, {1}, {3, 1}}, [][]int{{1}, {3, 1}, {3, 1}}},
+		{"empty", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			SortLex(vec)
+			if !slices.EqualFunc(vec, tt.want, slices.Equal[[]int]) {
+				t.Errorf("SortLex(%v) = %v, want %v", tt.vec, vec, tt.want)
+			}
+		})
+	}
+}
+
+// The rows are moved around, not copied: every row of the output is one of
+// the input's backing arrays, and equal rows keep their order
+func TestSortLexMovesRows(t *testing.T) {
+	a, b, c := []int{2}, []int{1, 5}, []int{1, 5}
+	vec := [][]int{a, b, c}
+	SortLex(vec)
+
+	if &vec[0][0] != &b[0] || &vec[1][0] != &c[0] || &vec[2][0] != &a[0] {
+		t.Errorf("SortLex = %v, rows not in the expected input order", vec)
+	}
+}
+
+func TestSortByteArrays16(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	vec := make([][16]byte, 1000)
+	for i := range vec {
+		rng.Read(vec[i][:])
+	}
+	// shared prefixes, so whole prefixes have to be compared
+	vec[1] = vec[0]
+	vec[1][15]++
+	vec[2] = vec[0]
+	vec[2][8]--
+	vec = append(vec, [16]byte{}, [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	in := slices.Clone(vec)
+
+	SortByteArrays16(vec)
+	want := slices.SortedFunc(slices.Values(in), func(a, b [16]byte) int { return bytes.Compare(a[:], b[:]) })
+	if !slices.Equal(vec, want) {
+		t.Errorf("SortByteArrays16 didn't sort")
+	}
+	if vec[0] != [16]byte{} || vec[len(vec)-1][0] != 0xff {
+		t.Errorf("SortByteArrays16 put %x first and %x last", vec[0], vec[len(vec)-1])
+	}
+}
+
+func TestSortBytesKey(t *testing.T) {
+	type record struct {
+		ID   [4]byte
+		Name string
+	}
+	vec := []record{{[4]byte{0, 0, 1, 0}, "b"}, {[4]byte{0, 0, 0, 9}, "a"}, {[4]byte{1, 0, 0, 0}, "d"}, {[4]byte{0, 0, 1, 0}, "c"}}
+	SortBytesKey(vec, func(r record) []byte { return r.ID[:] })
+
+	var got string
+	for _, r := range vec {
+		got += r.Name
+	}
+	// stable: the two equal IDs keep b before c
+	if got != "abcd" {
+		t.Errorf("SortBytesKey order = %q, want \"abcd\"", got)
+	}
+}
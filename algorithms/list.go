@@ -0,0 +1,59 @@
+package algorithms
+
+import "cmp"
+
+// Singly linked list node
+type Node[T any] struct {
+	Val  T
+	Next *Node[T]
+}
+
+// Merge sort for linked lists. The nodes are relinked, nothing is copied or
+// allocated, so besides the O(log n) recursion it is O(1) memory. Stable.
+// Returns the new head.
+func SortList[T Ordered](head *Node[T]) *Node[T] {
+	return SortListFunc(head, cmp.Compare[T])
+}
+
+func SortListFunc[T any](head *Node[T], cmp func(a, b T) int) *Node[T] {
+	if head == nil || head.Next == nil {
+		return head
+	}
+
+	// slow ends up at the end of the first half when fast runs off the list
+	slow, fast := head, head.Next
+	for fast != nil && fast.Next != nil {
+		slow = slow.Next
+		fast = fast.Next.Next
+	}
+
+	second := slow.Next
+	slow.Next = nil
+
+	return mergeLists(SortListFunc(head, cmp), SortListFunc(second, cmp), cmp)
+}
+
+func mergeLists[T any](a, b *Node[T], cmp func(a, b T) int) *Node[T] {
+	var dummy Node[T]
+	tail := &dummy
+
+	for a != nil && b != nil {
+		// <= so the first list wins ties, that is what keeps it stable
+		if cmp(a.Val, b.Val) <= 0 {
+			tail.Next = a
+			a = a.Next
+		} else {
+			tail.Next = b
+			b = b.Next
+		}
+		tail = tail.Next
+	}
+
+	if a != nil {
+		tail.Next = a
+	} else {
+		tail.Next = b
+	}
+
+	return dummy.Next
+}
@@ -0,0 +1,66 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func toList[T any](vals []T) *Node[T] {
+	var head *Node[T]
+	for i := len(vals) - 1; i >= 0; i-- {
+		head = &Node[T]{Val: vals[i], Next: head}
+	}
+	return head
+}
+
+func fromList[T any](head *Node[T]) []T {
+	var vals []T
+	for ; head != nil; head = head.Next {
+		vals = append(vals, head.Val)
+	}
+	return vals
+}
+
+func TestSortList(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, in := range [][]int{nil, {5}, {2, 1}, {3, 1, 2}, {4, 4, 1, 4}, SortedInts(100), ReverseSortedInts(101), RandomInts(1000, rng)} {
+		head := toList(in)
+		nodes := make(map[*Node[int]]bool)
+		for n := head; n != nil; n = n.Next {
+			nodes[n] = true
+		}
+
+		sorted := SortList(head)
+		got := fromList(sorted)
+		if want := slices.Sorted(slices.Values(in)); !slices.Equal(got, want) {
+			t.Fatalf("SortList(%v) = %v, want %v", in, got, want)
+		}
+
+		// relinked, not copied
+		for n := sorted; n != nil; n = n.Next {
+			if !nodes[n] {
+				t.Fatalf("SortList(%v) made a new node", in)
+			}
+		}
+	}
+}
+
+func TestSortListSingle(t *testing.T) {
+	node := &Node[int]{Val: 7}
+	if got := SortList(node); got != node || got.Next != nil {
+		t.Errorf("SortList on a single node = %+v", got)
+	}
+}
+
+func TestSortListFuncStable(t *testing.T) {
+	in := keyedInts(1000, 10, rand.New(rand.NewSource(1)))
+	key := func(v keyed) int { return v.Key }
+
+	got := fromList(SortListFunc(toList(in), ByField(key)))
+	want := slices.Clone(in)
+	slices.SortStableFunc(want, ByField(key))
+	if !slices.Equal(got, want) {
+		t.Errorf("SortListFunc isn't a stable sort by key")
+	}
+}
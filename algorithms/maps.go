@@ -0,0 +1,64 @@
+package algorithms
+
+import "cmp"
+
+type Pair[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// Keys of m in ascending order. Allocates exactly one slice of len(m)
+func SortedKeys[K Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	IntroSort(keys)
+	return keys
+}
+
+// Entries of m sorted by key. Allocates exactly one slice of len(m)
+func SortedPairs[K Ordered, V any](m map[K]V) []Pair[K, V] {
+	pairs := mapPairs(m)
+	// keys are unique, no need for a stable sort
+	QuickSortCmp(pairs, func(a, b Pair[K, V]) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return pairs
+}
+
+// Entries of m sorted by value, entries with equal values sorted by key so
+// the output is the same every time no matter the map iteration order
+func SortedByValue[K Ordered, V Ordered](m map[K]V) []Pair[K, V] {
+	pairs := mapPairs(m)
+	QuickSortCmp(pairs, func(a, b Pair[K, V]) int {
+		if c := cmp.Compare(a.Value, b.Value); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return pairs
+}
+
+// Same as SortedByValue but the biggest values first. Ties are still sorted
+// by ascending key, e.g. for a word frequency map the most common words come
+// first and words with the same count are alphabetical
+func SortedByValueDesc[K Ordered, V Ordered](m map[K]V) []Pair[K, V] {
+	pairs := mapPairs(m)
+	QuickSortCmp(pairs, func(a, b Pair[K, V]) int {
+		if c := cmp.Compare(b.Value, a.Value); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return pairs
+}
+
+func mapPairs[K comparable, V any](m map[K]V) []Pair[K, V] {
+	pairs := make([]Pair[K, V], 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, Pair[K, V]{k, v})
+	}
+	return pairs
+}
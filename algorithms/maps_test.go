@@ -0,0 +1,68 @@
+package algorithms
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortedByValue(t *testing.T) {
+	counts := map[string]int{"the": 5, "a": 5, "of": 2, "zebra": 1, "and": 5, "to": 2, "x": 1}
+
+	tests := []struct {
+		name   string
+		sorted func(map[string]int) []Pair[string, int]
+		want   []Pair[string, int]
+	}{
+		{"ascending", SortedByValue[string, int], []Pair[string, int]{
+			{"x", 1}, {"zebra", 1}, {"of", 2}, {"to", 2}, {"a", 5}, {"and", 5}, {"the", 5},
+		}},
+		// ties still by ascending key
+		{"descending", SortedByValueDesc[string, int], []Pair[string, int]{
+			{"a", 5}, {"and", 5}, {"the", 5}, {"of", 2}, {"to", 2}, {"x", 1}, {"zebra", 1},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// map iteration order changes from run to run, the output mustn't
+			for i := 0; i < 20; i++ {
+				if got := tt.sorted(counts); !slices.Equal(got, tt.want) {
+					t.Fatalf("%s = %v, want %v", tt.name, got, tt.want)
+				}
+			}
+			if got := tt.sorted(map[string]int{}); len(got) != 0 {
+				t.Errorf("%s of an empty map = %v", tt.name, got)
+			}
+		})
+	}
+}
+
+func TestSortedKeysAndPairs(t *testing.T) {
+	m := map[string]int{"pear": 3, "apple": 7, "fig": 3, "banana": 0, "": 1}
+
+	wantKeys := []string{"", "apple", "banana", "fig", "pear"}
+	if got := SortedKeys(m); !slices.Equal(got, wantKeys) {
+		t.Errorf("SortedKeys = %q, want %q", got, wantKeys)
+	}
+
+	wantPairs := []Pair[string, int]{{"", 1}, {"apple", 7}, {"banana", 0}, {"fig", 3}, {"pear", 3}}
+	if got := SortedPairs(m); !slices.Equal(got, wantPairs) {
+		t.Errorf("SortedPairs = %v, want %v", got, wantPairs)
+	}
+
+	// bigger than a few map buckets, every value still next to its key
+	big := make(map[int]string)
+	for i := 0; i < 1000; i++ {
+		big[(i*7919)%1000-500] = string(rune('a' + i%26))
+	}
+	keys := SortedKeys(big)
+	pairs := SortedPairs(big)
+	if len(keys) != len(big) || !slices.IsSorted(keys) {
+		t.Fatalf("SortedKeys on 1000 keys isn't sorted")
+	}
+	for i, p := range pairs {
+		if p.Key != keys[i] || p.Value != big[p.Key] {
+			t.Fatalf("SortedPairs[%d] = %v, want key %d with its value %q", i, p, keys[i], big[keys[i]])
+		}
+	}
+}
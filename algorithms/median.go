@@ -0,0 +1,158 @@
+package algorithms
+
+// Median of every window of window consecutive elements of vec, so element
+// i of the result is the median of vec[i:i+window]. Even sized windows
+// average the two middle elements. A window bigger than vec is shrunk to
+// len(vec), giving the median of the whole slice, and an empty vec returns
+// nil. Panics if window < 1.
+//
+// It keeps the smaller half of the window in a max-heap and the bigger half
+// in a min-heap, so the median is always at the top of one or both. Elements
+// leaving the window aren't searched for, they are only marked and thrown
+// away once they reach the top of their heap. O(n log window). T has to be a
+// number since the result is a float64, and NaNs aren't supported
+func SlidingMedian[T Number](vec []T, window int) []float64 {
+	if window < 1 {
+		panic("algorithms: SlidingMedian window has to be at least 1")
+	}
+	if len(vec) == 0 {
+		return nil
+	}
+	window = min(window, len(vec))
+
+	w := &medianWindow[T]{
+		lo:      medianHeap[T]{less: func(a, b T) bool { return a > b }},
+		hi:      medianHeap[T]{less: func(a, b T) bool { return a < b }},
+		delayed: make(map[T]int),
+	}
+
+	out := make([]float64, 0, len(vec)-window+1)
+	for i, val := range vec {
+		w.add(val)
+		if i >= window {
+			w.remove(vec[i-window])
+		}
+		if i >= window-1 {
+			out = append(out, w.median(window))
+		}
+	}
+	return out
+}
+
+type medianWindow[T Number] struct {
+	// lo has the smaller half and gets the extra element on odd windows
+	lo, hi medianHeap[T]
+	// how many elements of the heaps are still in them but not in the window
+	// anymore, loSize and hiSize don't count those
+	delayed        map[T]int
+	loSize, hiSize int
+}
+
+func (w *medianWindow[T]) add(val T) {
+	if w.loSize == 0 || val <= w.lo.top() {
+		w.lo.push(val)
+		w.loSize++
+	} else {
+		w.hi.push(val)
+		w.hiSize++
+	}
+	w.balance()
+}
+
+func (w *medianWindow[T]) remove(val T) {
+	w.delayed[val]++
+
+	// both tops are always in the window, so comparing with the top of lo
+	// tells which heap val is in
+	if val <= w.lo.top() {
+		w.loSize--
+		w.prune(&w.lo)
+	} else {
+		w.hiSize--
+		w.prune(&w.hi)
+	}
+	w.balance()
+}
+
+func (w *medianWindow[T]) balance() {
+	if w.loSize > w.hiSize+1 {
+		w.hi.push(w.lo.pop())
+		w.loSize--
+		w.hiSize++
+		w.prune(&w.lo)
+	} else if w.loSize < w.hiSize {
+		w.lo.push(w.hi.pop())
+		w.hiSize--
+		w.loSize++
+		w.prune(&w.hi)
+	}
+}
+
+// Pops elements that already left the window off the top of h
+func (w *medianWindow[T]) prune(h *medianHeap[T]) {
+	for len(h.vec) > 0 && w.delayed[h.top()] > 0 {
+		val := h.pop()
+		w.delayed[val]--
+		if w.delayed[val] == 0 {
+			delete(w.delayed, val)
+		}
+	}
+}
+
+func (w *medianWindow[T]) median(window int) float64 {
+	if window%2 == 1 {
+		return float64(w.lo.top())
+	}
+	return (float64(w.lo.top()) + float64(w.hi.top())) / 2
+}
+
+// Binary heap where the top is the element that is less than all the others,
+// so it's a min-heap or a max-heap depending on less
+type medianHeap[T Number] struct {
+	vec  []T
+	less func(a, b T) bool
+}
+
+func (h *medianHeap[T]) top() T {
+	return h.vec[0]
+}
+
+func (h *medianHeap[T]) push(val T) {
+	h.vec = append(h.vec, val)
+
+	// sift up
+	i := len(h.vec) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.vec[i], h.vec[parent]) {
+			break
+		}
+		h.vec[i], h.vec[parent] = h.vec[parent], h.vec[i]
+		i = parent
+	}
+}
+
+func (h *medianHeap[T]) pop() T {
+	val := h.vec[0]
+	n := len(h.vec) - 1
+	h.vec[0] = h.vec[n]
+	h.vec = h.vec[:n]
+
+	// sift down
+	i := 0
+	for {
+		best := i
+		left, right := 2*i+1, 2*i+2
+		if left < n && h.less(h.vec[left], h.vec[best]) {
+			best = left
+		}
+		if right < n && h.less(h.vec[right], h.vec[best]) {
+			best = right
+		}
+		if best == i {
+			return val
+		}
+		h.vec[i], h.vec[best] = h.vec[best], h.vec[i]
+		i = best
+	}
+}
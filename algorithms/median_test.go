@@ -0,0 +1,75 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Sorts a copy of every window
+func bruteForceMedians(vec []int, window int) []float64 {
+	window = min(window, len(vec))
+	var out []float64
+	for i := 0; i+window <= len(vec); i++ {
+		w := slices.Sorted(slices.Values(vec[i : i+window]))
+		m := float64(w[window/2])
+		if window%2 == 0 {
+			m = (float64(w[window/2-1]) + m) / 2
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestSlidingMedian(t *testing.T) {
+	tests := []struct {
+		name   string
+		vec    []int
+		window int
+		want   []float64
+	}{
+		{"odd window", []int{1, 3, -1, -3, 5, 3, 6, 7}, 3, []float64{1, -1, -1, 3, 5, 6}},
+		{"even window", []int{1, 3, -1, -3, 5, 3, 6, 7}, 4, []float64{0, 1, 1, 4, 5.5}},
+		{"window of one", []int{4, 2, 9}, 1, []float64{4, 2, 9}},
+		{"whole slice", []int{4, 2, 9, 1}, 4, []float64{3}},
+		{"window too big", []int{4, 2, 9}, 10, []float64{4}},
+		{"duplicates", []int{2, 2, 2, 1, 2, 2}, 3, []float64{2, 2, 2, 2}},
+		{"empty", nil, 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SlidingMedian(tt.vec, tt.window); !slices.Equal(got, tt.want) {
+				t.Errorf("SlidingMedian(%v, %d) = %v, want %v", tt.vec, tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlidingMedianMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 10, 100, 1000} {
+		for _, window := range []int{1, 2, 3, 4, 7, 16, 51, 1000} {
+			// few unique values make lots of ties and delayed removals
+			for _, vec := range [][]int{RandomInts(n, rng), FewUniqueInts(n, rng), SortedInts(n), ReverseSortedInts(n)} {
+				got := SlidingMedian(vec, window)
+				if want := bruteForceMedians(vec, window); !slices.Equal(got, want) {
+					t.Fatalf("n=%d window=%d: SlidingMedian = %v, want %v", n, window, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSlidingMedianPanics(t *testing.T) {
+	for _, window := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SlidingMedian with window %d didn't panic", window)
+				}
+			}()
+			SlidingMedian([]int{1, 2, 3}, window)
+		}()
+	}
+}
@@ -0,0 +1,50 @@
+package algorithms
+
+import "errors"
+
+var ErrEmptySlice = errors.New("algorithms: empty slice")
+
+// Smallest element of vec, ErrEmptySlice if there is none
+func Min[T Ordered](vec []T) (T, error) {
+	min, _, err := MinMax(vec)
+	return min, err
+}
+
+// Largest element of vec, ErrEmptySlice if there is none
+func Max[T Ordered](vec []T) (T, error) {
+	_, max, err := MinMax(vec)
+	return max, err
+}
+
+// Smallest and largest element of vec in a single pass, ErrEmptySlice if
+// there is none
+func MinMax[T Ordered](vec []T) (min T, max T, err error) {
+	if len(vec) == 0 {
+		return min, max, ErrEmptySlice
+	}
+
+	min, max = vec[0], vec[0]
+	for _, val := range vec[1:] {
+		// two separate ifs, the first element could be both
+		if val < min {
+			min = val
+		}
+
+		if val > max {
+			max = val
+		}
+	}
+
+	return min, max, nil
+}
+
+// lo if val < lo, hi if val > hi, val otherwise
+func Clamp[T Ordered](val, lo, hi T) T {
+	if val < lo {
+		return lo
+	}
+	if val > hi {
+		return hi
+	}
+	return val
+}
@@ -0,0 +1,55 @@
+package algorithms
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		name     string
+		vec      []int
+		min, max int
+		err      error
+	}{
+		{"nil", nil, 0, 0, ErrEmptySlice},
+		{"empty", []int{}, 0, 0, ErrEmptySlice},
+		{"single", []int{4}, 4, 4, nil},
+		{"min first", []int{1, 5, 3}, 1, 5, nil},
+		{"max first", []int{9, 5, 7}, 5, 9, nil},
+		{"negative", []int{-3, -8, -1}, -8, -1, nil},
+		{"all equal", []int{2, 2, 2}, 2, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, err := MinMax(tt.vec)
+			if !errors.Is(err, tt.err) || lo != tt.min || hi != tt.max {
+				t.Errorf("MinMax = %d, %d, %v, want %d, %d, %v", lo, hi, err, tt.min, tt.max, tt.err)
+			}
+			if got, err := Min(tt.vec); !errors.Is(err, tt.err) || got != tt.min {
+				t.Errorf("Min = %d, %v, want %d, %v", got, err, tt.min, tt.err)
+			}
+			if got, err := Max(tt.vec); !errors.Is(err, tt.err) || got != tt.max {
+				t.Errorf("Max = %d, %v, want %d, %v", got, err, tt.max, tt.err)
+			}
+		})
+	}
+}
+
+func TestClamp(t *testing.T) {
+	tests := []struct{ val, lo, hi, want int }{
+		{5, 0, 10, 5},
+		{-1, 0, 10, 0},
+		{11, 0, 10, 10},
+		{0, 0, 10, 0},
+		{10, 0, 10, 10},
+		{3, 3, 3, 3},
+	}
+
+	for _, tt := range tests {
+		if got := Clamp(tt.val, tt.lo, tt.hi); got != tt.want {
+			t.Errorf("Clamp(%d, %d, %d) = %d, want %d", tt.val, tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package algorithms
+
+import "math/bits"
+
+// Longest slice sortNetwork can handle
+const MaxNetworkSize = 8
+
+// Optimal (fewest comparators) sorting networks for lengths 2 to 8. Each pair
+// is a compare-exchange: after it vec[i] <= vec[j]. Length 8 takes 19
+// comparisons, insertion sort needs up to 28
+var sortingNetworks = [MaxNetworkSize + 1][][2]int{
+	2: {{0, 1}},
+	3: {{0, 2}, {0, 1}, {1, 2}},
+	4: {{0, 2}, {1, 3}, {0, 1}, {2, 3}, {1, 2}},
+	5: {{0, 3}, {1, 4}, {0, 2}, {1, 3}, {0, 1}, {2, 4}, {1, 2}, {3, 4}, {2, 3}},
+	6: {{0, 5}, {1, 3}, {2, 4}, {1, 2}, {3, 4}, {0, 3}, {2, 5}, {0, 1}, {2, 3},
+		{4, 5}, {1, 2}, {3, 4}},
+	7: {{0, 6}, {2, 3}, {4, 5}, {0, 2}, {1, 4}, {3, 6}, {0, 1}, {2, 5}, {3, 4},
+		{1, 2}, {4, 6}, {2, 3}, {4, 5}, {1, 2}, {3, 4}, {5, 6}},
+	8: {{0, 2}, {1, 3}, {4, 6}, {5, 7}, {0, 4}, {1, 5}, {2, 6}, {3, 7}, {0, 1},
+		{2, 3}, {4, 5}, {6, 7}, {2, 4}, {3, 5}, {1, 4}, {3, 6}, {1, 2}, {3, 4},
+		{5, 6}},
+}
+
+// Sorts slices of length <= MaxNetworkSize with a fixed sequence of
+// compare-exchanges, no loops depending on the data. Not stable, but for
+// Ordered values equal elements can't be told apart anyway (except -0 and +0)
+func sortNetwork[T Ordered](vec []T) {
+	for _, pair := range sortingNetworks[len(vec)] {
+		i, j := pair[0], pair[1]
+		// a plain swap instead of min/max, min/max would duplicate NaNs
+		if vec[j] < vec[i] {
+			vec[i], vec[j] = vec[j], vec[i]
+		}
+	}
+}
+
+// Used by QuickSort and IntroSort for their tiny ranges. MergeSort sticks to
+// InsertionSort since it has to stay stable
+func smallSort[T Ordered](vec []T) {
+	if len(vec) <= MaxNetworkSize {
+		sortNetwork(vec)
+	} else {
+		InsertionSort(vec)
+	}
+}
+
+// Bitonic sorting network. The sequence of compare-exchanges only depends on
+// the length, never on the data, which is what makes it a good fit for SIMD
+// and GPUs. Here it is mostly for learning, it does O(n log^2 n) comparisons.
+//
+// The network needs a power of two length, so vec is copied into a buffer
+// padded up to the next one. Padding slots are marked and always compare as
+// bigger than any real element, so they all end up at the back and only the
+// real elements are copied back. Not stable
+func BitonicSort[T Ordered](vec []T) {
+	n := len(vec)
+	if n <= 1 {
+		return
+	}
+
+	p := 1 << bits.Len(uint(n-1))
+	buf := make([]T, p)
+	pad := make([]bool, p)
+	copy(buf, vec)
+	for i := n; i < p; i++ {
+		pad[i] = true
+	}
+
+	greater := func(a, b int) bool {
+		if pad[a] || pad[b] {
+			return pad[a] && !pad[b]
+		}
+		return buf[a] > buf[b]
+	}
+
+	for k := 2; k <= p; k <<= 1 {
+		for j := k >> 1; j > 0; j >>= 1 {
+			for i := 0; i < p; i++ {
+				l := i ^ j
+				if l <= i {
+					continue
+				}
+
+				// blocks of size k alternate between ascending and
+				// descending, that is what makes them bitonic for the
+				// next round
+				ascending := i&k == 0
+				if ascending == greater(i, l) {
+					buf[i], buf[l] = buf[l], buf[i]
+					pad[i], pad[l] = pad[l], pad[i]
+				}
+			}
+		}
+	}
+
+	copy(vec, buf[:n])
+}
@@ -0,0 +1,151 @@
+package algorithms
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// Every permutation of 0..n-1, in Heap's algorithm order
+func permutations(n int) [][]int {
+	perm := SortedInts(n)
+	out := [][]int{slices.Clone(perm)}
+
+	c := make([]int, n)
+	for i := 0; i < n; {
+		if c[i] < i {
+			if i%2 == 0 {
+				perm[0], perm[i] = perm[i], perm[0]
+			} else {
+				perm[c[i]], perm[i] = perm[i], perm[c[i]]
+			}
+			out = append(out, slices.Clone(perm))
+			c[i]++
+			i = 0
+		} else {
+			c[i] = 0
+			i++
+		}
+	}
+	return out
+}
+
+func TestSortNetworkAllPermutations(t *testing.T) {
+	tests := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"sortNetwork", sortNetwork[int]},
+		{"smallSort", smallSort[int]},
+	}
+
+	for _, tt := range tests {
+		for n := 0; n <= MaxNetworkSize; n++ {
+			for _, perm := range permutations(n) {
+				vec := slices.Clone(perm)
+				tt.sort(vec)
+				if !slices.IsSorted(vec) {
+					t.Fatalf("%s(%v) = %v", tt.name, perm, vec)
+				}
+			}
+		}
+	}
+}
+
+// By the 0-1 principle a network sorts everything iff it sorts every
+// sequence of zeros and ones, which also covers inputs with duplicates
+func TestSortNetworkZeroOne(t *testing.T) {
+	for n := 0; n <= MaxNetworkSize; n++ {
+		for bitsSet := 0; bitsSet < 1<<n; bitsSet++ {
+			vec := make([]int, n)
+			for i := range vec {
+				vec[i] = bitsSet >> i & 1
+			}
+			in := slices.Clone(vec)
+			sortNetwork(vec)
+			if !slices.IsSorted(vec) {
+				t.Fatalf("sortNetwork(%v) = %v", in, vec)
+			}
+		}
+	}
+}
+
+// The network always does len(sortingNetworks[n]) comparisons. It never does
+// more than insertion sort's worst case, and on length 8 it beats insertion
+// sort's average over every permutation
+func TestSortNetworkFewerComparisons(t *testing.T) {
+	for n := 2; n <= MaxNetworkSize; n++ {
+		worst := 0
+		for _, perm := range permutations(n) {
+			worst = max(worst, InsertionSortInstrumented(perm).Comparisons)
+		}
+		if got := len(sortingNetworks[n]); got > worst {
+			t.Errorf("n=%d: network does %d comparisons, insertion sort at most %d", n, got, worst)
+		}
+	}
+
+	perms := permutations(8)
+	total := 0
+	for _, perm := range perms {
+		total += InsertionSortInstrumented(perm).Comparisons
+	}
+	avg := float64(total) / float64(len(perms))
+
+	if got := len(sortingNetworks[8]); float64(got) >= avg {
+		t.Errorf("n=8: network does %d comparisons, insertion sort %.2f on average", got, avg)
+	}
+}
+
+// Every permutation up to length 8 and every 0-1 sequence up to length 16,
+// which covers every non power of two length up to there
+func TestBitonicSortExhaustive(t *testing.T) {
+	for n := 0; n <= 8; n++ {
+		for _, perm := range permutations(n) {
+			vec := slices.Clone(perm)
+			BitonicSort(vec)
+			if !slices.Equal(vec, SortedInts(n)) {
+				t.Fatalf("BitonicSort(%v) = %v", perm, vec)
+			}
+		}
+	}
+
+	for n := 0; n <= 16; n++ {
+		for bitsSet := 0; bitsSet < 1<<n; bitsSet++ {
+			vec := make([]int, n)
+			for i := range vec {
+				vec[i] = bitsSet >> i & 1
+			}
+			in := slices.Clone(vec)
+			BitonicSort(vec)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+				t.Fatalf("BitonicSort(%v) = %v", in, vec)
+			}
+		}
+	}
+}
+
+// The padding holds zero values, so negative input would show one leaking
+// into the output, and input at the maximum shows a real element lost behind
+// the padding
+func TestBitonicSortPadding(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []int
+		want []int
+	}{
+		{"negative", []int{-3, -1, -2}, []int{-3, -2, -1}},
+		{"max values", []int{math.MaxInt, 1, math.MaxInt, math.MinInt, 0}, []int{math.MinInt, 0, 1, math.MaxInt, math.MaxInt}},
+		{"one past a power of two", append(ReverseSortedInts(16), -1), append([]int{-1}, SortedInts(16)...)},
+		{"power of two", ReverseSortedInts(32), SortedInts(32)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			BitonicSort(vec)
+			if !slices.Equal(vec, tt.want) {
+				t.Errorf("BitonicSort(%v) = %v, want %v", tt.vec, vec, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package algorithms
+
+import (
+	"cmp"
+	"runtime"
+	"sync"
+)
+
+// Flags for SortWith. The zero value is a plain ascending Sort
+type Options struct {
+	// Equal elements keep their original order
+	Stable bool
+	// Largest first
+	Descending bool
+	// Sort chunks of vec on up to GOMAXPROCS goroutines, slices smaller
+	// than ParallelThreshold are still sorted on the calling goroutine
+	Parallel bool
+	// Leaf size: any range of at most this many elements is insertion
+	// sorted, the whole slice as well as every piece the recursion splits it
+	// into. 0 leaves it to the algorithms' own cutoffs
+	Cutoff int
+}
+
+// One entry point for the configurable sorts, picks the algorithm from opts:
+//   - len(vec) <= Cutoff: insertion sort
+//   - Parallel: parallel merge sort, which is stable as well
+//   - Stable: MergeSort
+//   - otherwise: Sort
+//
+// Descending sorts with the order flipped when it has to be stable, and
+// otherwise just reverses the ascending result. With a Cutoff it's always a
+// merge sort, sequential or parallel, that insertion sorts its leaves of up
+// to Cutoff elements. Sort picks its own cutoffs, so it can't take one, and
+// a merge sort can't go quadratic on duplicates like QuickSort can.
+func SortWith[T Ordered](vec []T, opts Options) {
+	compare := cmp.Compare[T]
+	if opts.Descending {
+		compare = func(a, b T) int { return cmp.Compare(b, a) }
+	}
+
+	if opts.Cutoff > 0 {
+		sortWithCutoff(vec, opts, compare)
+		return
+	}
+
+	switch {
+	case opts.Parallel && len(vec) >= ParallelThreshold:
+		parallelMergeSort(vec, 0, compare)
+	case opts.Stable || opts.Parallel:
+		if opts.Descending {
+			MergeSortCmp(vec, compare)
+		} else {
+			MergeSort(vec)
+		}
+	default:
+		Sort(vec)
+		if opts.Descending {
+			Reverse(vec)
+		}
+	}
+}
+
+// The SortWith dispatch for a Cutoff, where everything goes through compare
+// so the cutoff can be passed down. Stable whatever opts.Stable says
+func sortWithCutoff[T any](vec []T, opts Options, compare func(a, b T) int) {
+	switch {
+	case len(vec) <= opts.Cutoff:
+		InsertionSortCmp(vec, compare)
+	case opts.Parallel && len(vec) >= ParallelThreshold:
+		parallelMergeSort(vec, opts.Cutoff, compare)
+	default:
+		tmp := make([]T, len(vec))
+		mergeSortCmpHelper(vec, tmp, 0, len(vec)-1, opts.Cutoff, compare)
+	}
+}
+
+// Sorts one chunk per goroutine, then merges neighbouring chunks in rounds,
+// each merge of a round on its own goroutine. Chunks never overlap so nothing
+// has to be locked. Stable. The chunks are merge sorted with leaves of up to
+// cutoff elements insertion sorted
+func parallelMergeSort[T any](vec []T, cutoff int, cmp func(a, b T) int) {
+	workers := min(runtime.GOMAXPROCS(0), len(vec))
+	chunk := (len(vec) + workers - 1) / workers
+
+	tmp := make([]T, len(vec))
+	parallelChunks(len(vec), func(_, lo, hi int) {
+		mergeSortCmpHelper(vec, tmp, lo, hi-1, cutoff, cmp)
+	})
+
+	for width := chunk; width < len(vec); width *= 2 {
+		var wg sync.WaitGroup
+		for start := 0; start < len(vec)-width; start += 2 * width {
+			mid := start + width - 1
+			end := min(start+2*width-1, len(vec)-1)
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				mergeCmp(vec, tmp, start, mid, end, cmp)
+			}()
+		}
+		wg.Wait()
+	}
+}
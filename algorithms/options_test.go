@@ -0,0 +1,112 @@
+package algorithms
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// Every combination of flags, on a slice under the cutoff, one over it and
+// one big enough to really go parallel. -0 and +0 tell whether equal
+// elements kept their order
+func TestSortWith(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	negZero := math.Copysign(0, -1)
+
+	for _, n := range []int{0, 1000, ParallelThreshold + 123} {
+		in := make([]float64, n)
+		for i := range in {
+			switch rng.Intn(3) {
+			case 0:
+				in[i] = negZero
+			case 1:
+				in[i] = 0
+			default:
+				in[i] = float64(rng.Intn(100) - 50)
+			}
+		}
+
+		for flags := 0; flags < 16; flags++ {
+			opts := Options{
+				Stable:     flags&1 != 0,
+				Descending: flags&2 != 0,
+				Parallel:   flags&4 != 0,
+			}
+			if flags&8 != 0 {
+				opts.Cutoff = 1000
+			}
+
+			t.Run(fmt.Sprintf("n=%d/%+v", n, opts), func(t *testing.T) {
+				vec := slices.Clone(in)
+				SortWith(vec, opts)
+
+				compare := cmp.Compare[float64]
+				if opts.Descending {
+					compare = func(a, b float64) int { return cmp.Compare(b, a) }
+				}
+				if !slices.IsSortedFunc(vec, compare) {
+					t.Fatalf("not sorted")
+				}
+				if !slices.Equal(slices.Sorted(slices.Values(vec)), slices.Sorted(slices.Values(in))) {
+					t.Fatalf("not a permutation of the input")
+				}
+
+				// insertion sort under the cutoff is stable too
+				stable := opts.Stable || opts.Parallel || n <= opts.Cutoff
+				if stable && !slices.Equal(zeroSigns(vec), zeroSigns(in)) {
+					t.Errorf("not stable")
+				}
+			})
+		}
+	}
+}
+
+// The Cutoff reaches the leaves of the recursion, not just the whole slice.
+// Merge sorting 2c reversed elements with a cutoff of c is two insertion
+// sorts of c reversed elements and a merge where the right half all goes
+// first: c(c-1)/2 twice plus c comparisons
+func TestSortWithCutoffLeaves(t *testing.T) {
+	const c = 50
+	comparisons := 0
+	counting := func(a, b int) int {
+		comparisons++
+		return cmp.Compare(a, b)
+	}
+
+	vec := ReverseSortedInts(2 * c)
+	sortWithCutoff(vec, Options{Stable: true, Cutoff: c}, counting)
+	if !slices.Equal(vec, SortedInts(2*c)) {
+		t.Fatalf("stable with a cutoff: not sorted")
+	}
+	if comparisons != c*c {
+		t.Errorf("stable with a cutoff of %d: %d comparisons, want %d", c, comparisons, c*c)
+	}
+
+	// parallel merge sorts its chunks with the same leaves, so a cutoff as
+	// big as a chunk makes it quadratic where a cutoff of 1 isn't. 64
+	// workers keep the chunks small enough for that to be quick
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(64))
+	const chunk = ParallelThreshold / 64
+
+	var shared atomic.Int64
+	in := RandomInts(ParallelThreshold, rand.New(rand.NewSource(1)))
+	for _, cutoff := range []int{1, chunk} {
+		shared.Store(0)
+		vec = slices.Clone(in)
+		sortWithCutoff(vec, Options{Parallel: true, Cutoff: cutoff}, func(a, b int) int {
+			shared.Add(1)
+			return cmp.Compare(a, b)
+		})
+		if !slices.IsSorted(vec) {
+			t.Fatalf("parallel, cutoff %d: not sorted", cutoff)
+		}
+		if quadratic := shared.Load() > int64(len(in)*chunk/8); quadratic != (cutoff > 1) {
+			t.Errorf("parallel, cutoff %d: %d comparisons", cutoff, shared.Load())
+		}
+	}
+}
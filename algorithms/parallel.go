@@ -0,0 +1,157 @@
+package algorithms
+
+import (
+	"math/bits"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Below this many elements the parallel sorts just run their serial version,
+// starting goroutines isn't worth it
+const ParallelThreshold = 1 << 16
+
+// Splits [0, n) into one chunk per worker and runs work on each chunk in its
+// own goroutine. Returns once all of them are done
+func parallelChunks(n int, work func(worker, lo, hi int)) {
+	workers := min(runtime.GOMAXPROCS(0), n)
+	chunk := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		lo := w * chunk
+		hi := min(lo+chunk, n)
+		if lo >= hi {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			work(w, lo, hi)
+		}()
+	}
+	wg.Wait()
+}
+
+// IntegerCountingSort with the work split across goroutines. Every goroutine
+// counts its own chunk into its own counts array, those get added up, and
+// then every goroutine writes its own chunk of the output. Nothing is shared
+// while writing, so there is nothing to lock. Memory is GOMAXPROCS*(max+1)
+// counters, when that goes over the serial sort's limit (see
+// parallelCountingOK) it runs IntegerCountingSort instead.
+func ParallelCountingSort(vec []uint) {
+	if len(vec) < ParallelThreshold {
+		IntegerCountingSort(vec)
+		return
+	}
+
+	max := slices.Max(vec)
+	workers := min(runtime.GOMAXPROCS(0), len(vec))
+	if !parallelCountingOK(max, len(vec), workers) {
+		IntegerCountingSort(vec)
+		return
+	}
+
+	local := make([][]int, workers)
+	parallelChunks(len(vec), func(w, lo, hi int) {
+		counts := make([]int, max+1)
+		for _, val := range vec[lo:hi] {
+			counts[val]++
+		}
+		local[w] = counts
+	})
+
+	// starts[val] is the index where the run of val begins
+	starts := make([]int, max+2)
+	for val := range starts[:max+1] {
+		total := 0
+		for _, counts := range local {
+			if counts != nil {
+				total += counts[val]
+			}
+		}
+		starts[val+1] = starts[val] + total
+	}
+
+	parallelChunks(len(vec), func(_, lo, hi int) {
+		// first value whose run reaches into [lo, hi)
+		val := sort.SearchInts(starts[1:], lo+1)
+		for i := lo; i < hi; i++ {
+			for starts[val+1] <= i {
+				val++
+			}
+			vec[i] = uint(val)
+		}
+	})
+}
+
+// Every worker has its own max+1 counters, so all of them together have to
+// fit in the limit countingRangeOK puts on the serial sort
+func parallelCountingOK(max uint, n, workers int) bool {
+	if max == ^uint(0) {
+		return false
+	}
+	return max+1 <= countingRangeLimit(n)/uint(workers)
+}
+
+// LSD radix sort in base 256 where every pass is split across goroutines.
+// Each goroutine builds the histogram of its own chunk, then from all of the
+// histograms every goroutine works out exactly where its elements go. Chunk w
+// writes after chunk w-1 for every digit, so the scatter stays stable, and no
+// two goroutines write the same spot. Passes run one after the other.
+func ParallelRadixSort(vec []uint) {
+	if len(vec) < ParallelThreshold {
+		IntRadixSort(vec)
+		return
+	}
+
+	max := slices.Max(vec)
+	passes := (bits.Len(max) + 7) / 8
+
+	workers := min(runtime.GOMAXPROCS(0), len(vec))
+	counts := make([][256]int, workers)
+
+	src := vec
+	dst := make([]uint, len(vec))
+
+	for pass := 0; pass < passes; pass++ {
+		shift := uint(pass * 8)
+
+		// all of them, parallelChunks skips a worker that has no chunk and
+		// its counts from the last pass would still be there
+		clear(counts)
+		parallelChunks(len(src), func(w, lo, hi int) {
+			for _, val := range src[lo:hi] {
+				counts[w][(val>>shift)&0xFF]++
+			}
+		})
+
+		// turn the counts into the index every worker starts writing each
+		// digit at
+		offset := 0
+		for d := 0; d < 256; d++ {
+			for w := range counts {
+				c := counts[w][d]
+				counts[w][d] = offset
+				offset += c
+			}
+		}
+
+		parallelChunks(len(src), func(w, lo, hi int) {
+			next := &counts[w]
+			for _, val := range src[lo:hi] {
+				d := (val >> shift) & 0xFF
+				dst[next[d]] = val
+				next[d]++
+			}
+		})
+
+		src, dst = dst, src
+	}
+
+	if passes%2 == 1 {
+		copy(vec, src)
+	}
+}
@@ -0,0 +1,154 @@
+package algorithms
+
+import (
+	"math/rand"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+// n random values in [0, limit)
+func randomUints(n int, limit uint64, rng *rand.Rand) []uint {
+	vec := make([]uint, n)
+	for i := range vec {
+		vec[i] = uint(rng.Uint64() % limit)
+	}
+	return vec
+}
+
+// Run with -race, the goroutines count and write disjoint chunks
+func TestParallelCountingSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 1000, ParallelThreshold - 1, ParallelThreshold, 300_001} {
+		for _, limit := range []uint64{1, 3, 1000, 1 << 16, 1 << 22} {
+			in := randomUints(n, limit, rng)
+			vec := slices.Clone(in)
+			ParallelCountingSort(vec)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+				t.Fatalf("n=%d, values below %d: not sorted", n, limit)
+			}
+		}
+	}
+}
+
+func TestParallelCountingOK(t *testing.T) {
+	const n = 1 << 20
+	limit := uint(CountingRangeFactor * n)
+	tests := []struct {
+		max     uint
+		workers int
+		want    bool
+	}{
+		{limit - 1, 1, true},
+		{limit, 1, false},
+		{limit/8 - 1, 8, true},
+		{limit / 8, 8, false},
+		// fine for the serial sort, too much once every worker has a copy
+		{limit/2 - 1, 64, false},
+		{^uint(0), 1, false},
+	}
+
+	for _, tt := range tests {
+		if got := parallelCountingOK(tt.max, n, tt.workers); got != tt.want {
+			t.Errorf("parallelCountingOK(%d, %d, %d) = %v, want %v", tt.max, n, tt.workers, got, tt.want)
+		}
+	}
+}
+
+// The 50M runs need a few hundred megabytes, pick them with -bench '/50M'
+func BenchmarkParallelCountingSort(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		sort func([]uint)
+	}{
+		{"ParallelCountingSort", ParallelCountingSort},
+		{"IntegerCountingSort", IntegerCountingSort},
+	}
+	sizes := []struct {
+		name string
+		n    int
+	}{
+		{"1M", 1 << 20},
+		{"50M", 50_000_000},
+	}
+
+	for _, size := range sizes {
+		var input []uint
+		for _, bm := range benchmarks {
+			b.Run(bm.name+"/"+size.name, func(b *testing.B) {
+				if input == nil {
+					input = randomUints(size.n, 1<<16, rand.New(rand.NewSource(BenchmarkSeed)))
+				}
+				vec := make([]uint, len(input))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					copy(vec, input)
+					bm.sort(vec)
+				}
+			})
+		}
+	}
+}
+
+func TestParallelRadixSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 1000, ParallelThreshold - 1, ParallelThreshold, 300_001} {
+		// odd and even pass counts, and values using every byte
+		for _, limit := range []uint64{1, 200, 1 << 16, 1 << 40, ^uint64(0)} {
+			in := randomUints(n, limit, rng)
+			got, want := slices.Clone(in), slices.Clone(in)
+			ParallelRadixSort(got)
+			IntRadixSort(want)
+			if !slices.Equal(got, want) || !slices.IsSorted(got) {
+				t.Fatalf("n=%d, values below %d: ParallelRadixSort differs from IntRadixSort", n, limit)
+			}
+		}
+	}
+}
+
+// 65537 elements over 1000 workers are chunks of 66, which only takes 993 of
+// the workers. The other 7 get nothing and their counts must still be zero
+func TestParallelRadixSortIdleWorkers(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1000))
+
+	in := randomUints(ParallelThreshold+1, 1<<40, rand.New(rand.NewSource(1)))
+	got := slices.Clone(in)
+	ParallelRadixSort(got)
+	if !slices.Equal(got, slices.Sorted(slices.Values(in))) {
+		t.Errorf("not sorted with idle workers")
+	}
+}
+
+func BenchmarkParallelRadixSort(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		sort func([]uint)
+	}{
+		{"ParallelRadixSort", ParallelRadixSort},
+		{"IntRadixSort", IntRadixSort},
+	}
+	sizes := []struct {
+		name string
+		n    int
+	}{
+		{"1M", 1 << 20},
+		{"20M", 20_000_000},
+	}
+
+	for _, size := range sizes {
+		var input []uint
+		for _, bm := range benchmarks {
+			b.Run(bm.name+"/"+size.name, func(b *testing.B) {
+				if input == nil {
+					input = randomUints(size.n, ^uint64(0), rand.New(rand.NewSource(BenchmarkSeed)))
+				}
+				vec := make([]uint, len(input))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					copy(vec, input)
+					bm.sort(vec)
+				}
+			})
+		}
+	}
+}
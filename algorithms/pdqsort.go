@@ -0,0 +1,286 @@
+package algorithms
+
+import "math/bits"
+
+// Pattern-defeating quicksort, same idea as what the standard library uses.
+// It's a quicksort that notices when things go wrong:
+//   - small ranges are finished with insertion sort
+//   - big ranges pick the pivot with a median of medians (Tukey's ninther)
+//   - while picking the pivot it notices sorted and reverse sorted input and
+//     finishes those in linear time
+//   - if the pivot equals the previous pivot the range is full of duplicates,
+//     so all the equal elements are put aside in one go
+//   - unbalanced partitions are counted, each one shuffles a few elements to
+//     break the pattern and after too many it falls back to HeapSort
+func PDQSort[T Ordered](vec []T) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	limit := bits.Len(uint(len(vec)))
+	pdqSortHelper(vec, 0, len(vec), limit)
+}
+
+const (
+	// Ranges this small are finished with InsertionSort
+	pdqInsertionSortCutoff = 12
+	// Ranges at least this big use the ninther to pick a pivot
+	pdqNintherThreshold = 50
+)
+
+type sortedHint int
+
+const (
+	unknownHint sortedHint = iota
+	increasingHint
+	decreasingHint
+)
+
+// Works on vec[start:end], end is exclusive here unlike quickSortHelper
+func pdqSortHelper[T Ordered](vec []T, start int, end int, limit int) {
+	wasBalanced := true
+	wasPartitioned := true
+
+	for {
+		length := end - start
+
+		if length <= pdqInsertionSortCutoff {
+			InsertionSort(vec[start:end])
+			return
+		}
+
+		// Too many bad pivots, stop trusting quicksort for this range
+		if limit == 0 {
+			HeapSort(vec[start:end])
+			return
+		}
+
+		if !wasBalanced {
+			breakPatterns(vec, start, end)
+			limit--
+		}
+
+		pivot, hint := choosePivot(vec, start, end)
+		if hint == decreasingHint {
+			Reverse(vec[start:end])
+			// the pivot moved with everything else
+			pivot = (end - 1) - (pivot - start)
+			hint = increasingHint
+		}
+
+		// Looks sorted already, try to finish it with a few moves
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSort(vec, start, end) {
+				return
+			}
+		}
+
+		// vec[start-1] is the pivot of the parent range and it is <= everything
+		// in this range. If it isn't < the new pivot they are equal, so put
+		// everything equal to the pivot at the front and skip over it
+		if start > 0 && !(vec[start-1] < vec[pivot]) {
+			start = partitionEqual(vec, start, end, pivot)
+			continue
+		}
+
+		mid, alreadyPartitioned := pdqPartition(vec, start, end, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		// Recurse into the smaller side and loop on the bigger one, that keeps
+		// the stack at O(log n)
+		leftLen, rightLen := mid-start, end-mid-1
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqSortHelper(vec, start, mid, limit)
+			start = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqSortHelper(vec, mid+1, end, limit)
+			end = mid
+		}
+	}
+}
+
+// Hoare style partition of vec[start:end] around vec[pivot]. Returns where
+// the pivot ended up, and whether the range was partitioned already (no
+// swaps were needed)
+func pdqPartition[T Ordered](vec []T, start int, end int, pivot int) (int, bool) {
+	vec[start], vec[pivot] = vec[pivot], vec[start]
+	i, j := start+1, end-1
+
+	for i <= j && vec[i] < vec[start] {
+		i++
+	}
+	for i <= j && !(vec[j] < vec[start]) {
+		j--
+	}
+	if i > j {
+		vec[j], vec[start] = vec[start], vec[j]
+		return j, true
+	}
+	vec[i], vec[j] = vec[j], vec[i]
+	i++
+	j--
+
+	for {
+		for i <= j && vec[i] < vec[start] {
+			i++
+		}
+		for i <= j && !(vec[j] < vec[start]) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		vec[i], vec[j] = vec[j], vec[i]
+		i++
+		j--
+	}
+
+	vec[j], vec[start] = vec[start], vec[j]
+	return j, false
+}
+
+// Moves everything equal to vec[pivot] to the front of vec[start:end] and
+// returns where the rest begins. Only called when nothing in the range is
+// smaller than the pivot
+func partitionEqual[T Ordered](vec []T, start int, end int, pivot int) int {
+	vec[start], vec[pivot] = vec[pivot], vec[start]
+	i, j := start+1, end-1
+
+	for {
+		for i <= j && !(vec[start] < vec[i]) {
+			i++
+		}
+		for i <= j && vec[start] < vec[j] {
+			j--
+		}
+		if i > j {
+			break
+		}
+		vec[i], vec[j] = vec[j], vec[i]
+		i++
+		j--
+	}
+
+	return i
+}
+
+// Insertion sort that gives up after fixing a handful of out of order pairs.
+// Returns true if vec[start:end] ended up sorted
+func partialInsertionSort[T Ordered](vec []T, start int, end int) bool {
+	const (
+		maxSteps = 5
+		// don't bother shifting anything on short ranges
+		shortestShifting = 50
+	)
+
+	i := start + 1
+	for step := 0; step < maxSteps; step++ {
+		for i < end && !(vec[i] < vec[i-1]) {
+			i++
+		}
+
+		if i == end {
+			return true
+		}
+
+		if end-start < shortestShifting {
+			return false
+		}
+
+		vec[i], vec[i-1] = vec[i-1], vec[i]
+
+		// shift the smaller one to the left
+		for j := i - 1; j > start && vec[j] < vec[j-1]; j-- {
+			vec[j], vec[j-1] = vec[j-1], vec[j]
+		}
+
+		// shift the bigger one to the right
+		for j := i + 1; j < end && vec[j] < vec[j-1]; j++ {
+			vec[j], vec[j-1] = vec[j-1], vec[j]
+		}
+	}
+
+	return false
+}
+
+// Swaps a few elements around the middle with pseudo random ones, so an
+// adversarial pattern doesn't keep producing bad pivots
+func breakPatterns[T Ordered](vec []T, start int, end int) {
+	length := end - start
+	if length < 8 {
+		return
+	}
+
+	random := xorshift(length)
+	modulus := uint(1) << bits.Len(uint(length))
+
+	idx := start + (length/4)*2 - 1
+	for i := 0; i < 3; i++ {
+		other := int(uint(random.next()) & (modulus - 1))
+		if other >= length {
+			other -= length
+		}
+		vec[idx-1+i], vec[start+other] = vec[start+other], vec[idx-1+i]
+	}
+}
+
+type xorshift uint64
+
+func (r *xorshift) next() uint64 {
+	*r ^= *r << 13
+	*r ^= *r >> 7
+	*r ^= *r << 17
+	return uint64(*r)
+}
+
+// Picks the pivot index for vec[start:end]. Looks at 3 elements, or 9 on big
+// ranges, and counts how many were out of order. None means the range is
+// probably increasing, all of them means it is probably decreasing
+func choosePivot[T Ordered](vec []T, start int, end int) (int, sortedHint) {
+	// 3 medians of 3 swaps each, plus the median of those
+	const maxSwaps = 4 * 3
+
+	length := end - start
+	swaps := 0
+	i := start + length/4*1
+	j := start + length/4*2
+	k := start + length/4*3
+
+	if length >= 8 {
+		if length >= pdqNintherThreshold {
+			i = medianCounted(vec, i-1, i, i+1, &swaps)
+			j = medianCounted(vec, j-1, j, j+1, &swaps)
+			k = medianCounted(vec, k-1, k, k+1, &swaps)
+		}
+		j = medianCounted(vec, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, increasingHint
+	case maxSwaps:
+		return j, decreasingHint
+	default:
+		return j, unknownHint
+	}
+}
+
+// Index of the median of vec[a], vec[b] and vec[c]. swaps is bumped for every
+// pair that was out of order
+func medianCounted[T Ordered](vec []T, a, b, c int, swaps *int) int {
+	a, b = order2(vec, a, b, swaps)
+	b, c = order2(vec, b, c, swaps)
+	_, b = order2(vec, a, b, swaps)
+	return b
+}
+
+func order2[T Ordered](vec []T, a, b int, swaps *int) (int, int) {
+	if vec[b] < vec[a] {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
@@ -0,0 +1,62 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Inputs that take plain quicksort pivots apart
+func patternInputs(n int, rng *rand.Rand) map[string][]int {
+	organPipe := make([]int, n)
+	sawtooth := make([]int, n)
+	for i := range organPipe {
+		organPipe[i] = min(i, n-i)
+		sawtooth[i] = i % 100
+	}
+	return map[string][]int{
+		"random":     RandomInts(n, rng),
+		"sorted":     SortedInts(n),
+		"reverse":    ReverseSortedInts(n),
+		"few unique": FewUniqueInts(n, rng),
+		"organ pipe": organPipe,
+		"sawtooth":   sawtooth,
+	}
+}
+
+func TestPDQSortPatterns(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, pdqInsertionSortCutoff, pdqNintherThreshold, 1000, 100_000} {
+		for name, in := range patternInputs(n, rng) {
+			vec := slices.Clone(in)
+			PDQSort(vec)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+				t.Errorf("n=%d: PDQSort didn't sort %s input", n, name)
+			}
+		}
+	}
+}
+
+func BenchmarkPDQSort(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"PDQSort", PDQSort[int]},
+		{"QuickSort", QuickSort[int]},
+	}
+
+	inputs := patternInputs(1<<16, rand.New(rand.NewSource(BenchmarkSeed)))
+	for _, pattern := range []string{"random", "sorted", "reverse", "few unique", "organ pipe"} {
+		for _, bm := range benchmarks {
+			b.Run(bm.name+"/"+pattern, func(b *testing.B) {
+				input := inputs[pattern]
+				vec := make([]int, len(input))
+				for i := 0; i < b.N; i++ {
+					copy(vec, input)
+					bm.sort(vec)
+				}
+			})
+		}
+	}
+}
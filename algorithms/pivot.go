@@ -0,0 +1,102 @@
+package algorithms
+
+import "math/rand"
+
+// How QuickSortPivot picks its pivot
+type PivotStrategy int
+
+const (
+	PivotMedianOfThree PivotStrategy = iota
+	PivotFirst
+	PivotLast
+	PivotMiddle
+	PivotRandom
+	// median of three medians of three, spread over the whole range
+	PivotNinther
+)
+
+// QuickSort with a chosen pivot strategy, to see how much the pivot matters.
+// PivotFirst and PivotLast go quadratic on sorted input, the rest don't. It
+// only recurses into the smaller side, so even the bad ones won't blow the
+// stack, they are just slow
+func QuickSortPivot[T Ordered](vec []T, strategy PivotStrategy) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	quickSortPivotHelper(vec, 0, len(vec)-1, strategy)
+}
+
+func quickSortPivotHelper[T Ordered](vec []T, start int, end int, strategy PivotStrategy) {
+	for start < end {
+		pivot := partitionAt(vec, start, end, choosePivotIndex(vec, start, end, strategy))
+		if pivot-start < end-pivot {
+			quickSortPivotHelper(vec, start, pivot-1, strategy)
+			start = pivot + 1
+		} else {
+			quickSortPivotHelper(vec, pivot+1, end, strategy)
+			end = pivot - 1
+		}
+	}
+}
+
+func choosePivotIndex[T Ordered](vec []T, start int, end int, strategy PivotStrategy) int {
+	mid := start + (end-start)/2
+
+	switch strategy {
+	case PivotFirst:
+		return start
+	case PivotLast:
+		return end
+	case PivotMiddle:
+		return mid
+	case PivotRandom:
+		return start + rand.Intn(end-start+1)
+	case PivotNinther:
+		// not enough elements for 9 distinct samples
+		if end-start < 8 {
+			return medianOfThree(vec, start, mid, end)
+		}
+		step := (end - start) / 8
+		a := medianOfThree(vec, start, start+step, start+2*step)
+		b := medianOfThree(vec, mid-step, mid, mid+step)
+		c := medianOfThree(vec, end-2*step, end-step, end)
+		return medianOfThree(vec, a, b, c)
+	default:
+		return medianOfThree(vec, start, mid, end)
+	}
+}
+
+// QuickSort that hands ranges of up to cutoff elements to leaf instead of
+// the built in sorting networks and InsertionSort, to try out other leaf
+// strategies. leaf has to fully sort the slice it gets. cutoff < 1 means no
+// leaves at all and a nil leaf uses the default one. Pivots are the same
+// median of three QuickSort uses
+func QuickSortLeaf[T Ordered](vec []T, cutoff int, leaf Sorter[T]) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	if leaf == nil {
+		leaf = smallSort[T]
+	}
+
+	quickSortLeafHelper(vec, 0, len(vec)-1, max(cutoff, 1), leaf)
+}
+
+func quickSortLeafHelper[T Ordered](vec []T, start int, end int, cutoff int, leaf Sorter[T]) {
+	for end-start+1 > cutoff {
+		pivot := partition(vec, start, end)
+		if pivot-start < end-pivot {
+			quickSortLeafHelper(vec, start, pivot-1, cutoff, leaf)
+			start = pivot + 1
+		} else {
+			quickSortLeafHelper(vec, pivot+1, end, cutoff, leaf)
+			end = pivot - 1
+		}
+	}
+
+	if start < end {
+		leaf(vec[start : end+1])
+	}
+}
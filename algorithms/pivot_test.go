@@ -0,0 +1,101 @@
+package algorithms
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+var pivotStrategies = []struct {
+	name     string
+	strategy PivotStrategy
+}{
+	{"MedianOfThree", PivotMedianOfThree},
+	{"First", PivotFirst},
+	{"Last", PivotLast},
+	{"Middle", PivotMiddle},
+	{"Random", PivotRandom},
+	{"Ninther", PivotNinther},
+}
+
+func TestQuickSortPivot(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, s := range pivotStrategies {
+		for _, n := range []int{0, 1, 2, 3, 8, 9, 10, 100, 2000} {
+			for name, in := range patternInputs(n, rng) {
+				vec := slices.Clone(in)
+				QuickSortPivot(vec, s.strategy)
+				if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+					t.Fatalf("%s, n=%d: didn't sort %s input", s.name, n, name)
+				}
+			}
+		}
+	}
+}
+
+// Swapping the leaf sort doesn't change the result, and the leaf only ever
+// gets ranges of at most cutoff elements
+func TestQuickSortLeaf(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	leaves := []struct {
+		name string
+		leaf Sorter[int]
+	}{
+		{"default", nil},
+		{"InsertionSort", InsertionSort[int]},
+		{"BitonicSort", BitonicSort[int]},
+		{"HeapSort", HeapSort[int]},
+	}
+
+	for _, l := range leaves {
+		for _, cutoff := range []int{0, 1, 4, 16, 64} {
+			for _, n := range []int{0, 1, 2, 15, 16, 17, 100, 2000} {
+				for name, in := range patternInputs(n, rng) {
+					calls, biggest := 0, 0
+					leaf := func(v []int) {
+						calls++
+						biggest = max(biggest, len(v))
+						if l.leaf == nil {
+							smallSort(v)
+						} else {
+							l.leaf(v)
+						}
+					}
+
+					vec := slices.Clone(in)
+					QuickSortLeaf(vec, cutoff, leaf)
+					want := slices.Clone(in)
+					QuickSort(want)
+					if !slices.Equal(vec, want) {
+						t.Fatalf("%s leaf, cutoff %d, n=%d: didn't sort %s input", l.name, cutoff, n, name)
+					}
+					if biggest > max(cutoff, 1) {
+						t.Fatalf("%s leaf, cutoff %d: got a range of %d", l.name, cutoff, biggest)
+					}
+					if cutoff <= 1 && calls > 0 {
+						t.Fatalf("cutoff %d still called the leaf %d times", cutoff, calls)
+					}
+				}
+			}
+		}
+	}
+}
+
+// PivotFirst on sorted input picks the smallest element every time, so each
+// partition only peels off one element and the sort is quadratic. Compare
+// the ns/op of the sizes
+func BenchmarkQuickSortPivot(b *testing.B) {
+	for _, s := range pivotStrategies {
+		for _, n := range []int{1000, 4000, 16000} {
+			input := SortedInts(n)
+			b.Run(fmt.Sprintf("%s/sorted/%d", s.name, n), func(b *testing.B) {
+				vec := make([]int, n)
+				for i := 0; i < b.N; i++ {
+					copy(vec, input)
+					QuickSortPivot(vec, s.strategy)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package algorithms
+
+import "cmp"
+
+// Where SortPointers puts nil pointers
+type NilPolicy int
+
+const (
+	NilsLast NilPolicy = iota
+	NilsFirst
+)
+
+// Sorts pointers by the values they point to. nil pointers all go to the
+// front or the back depending on policy. Stable, so pointers to equal values
+// keep their order
+func SortPointers[T Ordered](vec []*T, policy NilPolicy) {
+	// nil compares as smaller than everything for NilsFirst, bigger for NilsLast
+	nilOrder := 1
+	if policy == NilsFirst {
+		nilOrder = -1
+	}
+
+	MergeSortCmp(vec, func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return nilOrder
+		case b == nil:
+			return -nilOrder
+		default:
+			return cmp.Compare(*a, *b)
+		}
+	})
+}
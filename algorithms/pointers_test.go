@@ -0,0 +1,67 @@
+package algorithms
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortPointers(t *testing.T) {
+	p := func(v int) *int { return &v }
+	// two pointers to equal values, to check they keep their order
+	five, otherFive := p(5), p(5)
+	in := []*int{nil, p(3), five, nil, p(1), otherFive, nil, p(4)}
+
+	tests := []struct {
+		name   string
+		policy NilPolicy
+		vals   []int
+		nils   []int // indices that must be nil
+	}{
+		{"NilsLast", NilsLast, []int{1, 3, 4, 5, 5}, []int{5, 6, 7}},
+		{"NilsFirst", NilsFirst, []int{1, 3, 4, 5, 5}, []int{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(in)
+			SortPointers(vec, tt.policy)
+
+			var vals []int
+			for i, ptr := range vec {
+				if slices.Contains(tt.nils, i) != (ptr == nil) {
+					t.Fatalf("nil in the wrong place: %v", vec)
+				}
+				if ptr != nil {
+					vals = append(vals, *ptr)
+				}
+			}
+			if !slices.Equal(vals, tt.vals) {
+				t.Errorf("values %v, want %v", vals, tt.vals)
+			}
+			if i := slices.Index(vec, five); vec[i+1] != otherFive {
+				t.Errorf("pointers to equal values swapped places")
+			}
+		})
+	}
+}
+
+func TestSortPointersEdgeCases(t *testing.T) {
+	for _, policy := range []NilPolicy{NilsLast, NilsFirst} {
+		SortPointers[int](nil, policy)
+
+		allNil := []*int{nil, nil, nil}
+		SortPointers(allNil, policy)
+		for _, ptr := range allNil {
+			if ptr != nil {
+				t.Errorf("all nil input came back with %v", allNil)
+			}
+		}
+
+		one := 7
+		single := []*int{&one}
+		SortPointers(single, policy)
+		if single[0] != &one {
+			t.Errorf("single pointer was changed")
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// Radix sort for any integer type, one byte per pass. The number of passes
+// comes from the width of T (1 for int8, 8 for int64 ...). Signed values get
+// their sign bit flipped so negative numbers sort before positive ones.
+func RadixSort[T Integer](vec []T) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	width, signed := integerInfo[T]()
+
+	var signBit uint64
+	if signed {
+		signBit = 1 << (width - 1)
+	}
+
+	mask := ^uint64(0) >> (64 - width)
+	key := func(val T) uint64 {
+		return (uint64(val) & mask) ^ signBit
+	}
+
+	radixSortBytes(vec, width/8, key)
+}
+
+// Width of T in bits and whether it is signed
+func integerInfo[T Integer]() (width uint, signed bool) {
+	var zero T
+	signed = ^zero < zero
+
+	// keep shifting a 1 to the left until it falls off
+	for x := T(1); x != 0; x <<= 1 {
+		width++
+	}
+	return width, signed
+}
+
+// LSD radix sort in base 256 on the low passes bytes of key(val). Stable, and
+// it ping-pongs between vec and one buffer instead of allocating every pass
+func radixSortBytes[T any](vec []T, passes uint, key func(T) uint64) {
+	src := vec
+	dst := make([]T, len(vec))
+
+	for pass := uint(0); pass < passes; pass++ {
+		shift := pass * 8
+		var counts [257]int
+
+		for _, val := range src {
+			counts[(key(val)>>shift)&0xFF+1]++
+		}
+
+		// every element has the same byte here, nothing would move
+		if counts[(key(src[0])>>shift)&0xFF+1] == len(src) {
+			continue
+		}
+
+		for i := 1; i < len(counts); i++ {
+			counts[i] += counts[i-1]
+		}
+
+		for _, val := range src {
+			b := (key(val) >> shift) & 0xFF
+			dst[counts[b]] = val
+			counts[b]++
+		}
+
+		src, dst = dst, src
+	}
+
+	// after an odd number of real passes the result is in the buffer
+	if &src[0] != &vec[0] {
+		copy(vec, src)
+	}
+}
+
+// Base 0 means NumDigits, Passes 0 means as many as the max value needs
+type RadixConfig struct {
+	Base   uint
+	Passes int
+}
+
+// Biggest base IntRadixSortConfig accepts, it allocates Base counters
+const MaxRadixBase = 1 << 16
+
+// IntRadixSort with a custom base and optionally a fixed number of passes.
+// With Passes set, only the lowest Passes digits are looked at, so e.g.
+// {Base: 10, Passes: 3} groups values by their last 3 decimal digits. That is
+// only a grouping, not a full sort, unless Passes covers every digit of the
+// max value. Values with the same low digits keep their order.
+func IntRadixSortConfig(vec []uint, cfg RadixConfig) error {
+	base := cfg.Base
+	if base == 0 {
+		base = NumDigits
+	}
+
+	if base < 2 || base > MaxRadixBase {
+		return fmt.Errorf("algorithms: radix base %d out of range [2, %d]", base, MaxRadixBase)
+	}
+
+	if cfg.Passes < 0 {
+		return fmt.Errorf("algorithms: radix passes %d is negative", cfg.Passes)
+	}
+
+	if len(vec) <= 1 {
+		return nil
+	}
+
+	max := slices.Max(vec)
+	var exp uint = 1
+
+	for pass := 0; cfg.Passes == 0 || pass < cfg.Passes; pass++ {
+		if cfg.Passes == 0 && max/exp == 0 {
+			break
+		}
+
+		radixIntCountSort(vec, exp, base, false)
+
+		// the next exp would overflow, there are no digits left anyway
+		if exp > max/base {
+			break
+		}
+		exp *= base
+	}
+
+	return nil
+}
+
+// Stable LSD radix sort of any records by an unsigned key, e.g. a uint32 id.
+// Records with equal keys keep their order. Uses one scratch slice the size
+// of vec and only as many byte passes as the biggest key needs
+func RadixSortByKey[T any](vec []T, key func(T) uint64) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	var maxKey uint64
+	for _, val := range vec {
+		maxKey = max(maxKey, key(val))
+	}
+
+	passes := uint(bits.Len64(maxKey)+7) / 8
+	radixSortBytes(vec, passes, key)
+}
+
+// Stable counting sort of any records by a small int key in [0, maxKey], e.g.
+// a category. Same prefix sum placement as GeneralCountingSort, so it is
+// O(n + maxKey) and records with equal keys keep their order. key is called
+// twice per record. Panics if maxKey is negative or a key is outside of
+// [0, maxKey]
+func CountingSortKeyed[T any](vec []T, key func(T) int, maxKey int) {
+	if maxKey < 0 {
+		panic(fmt.Sprintf("algorithms: CountingSortKeyed maxKey %d is negative", maxKey))
+	}
+	if len(vec) <= 1 {
+		return
+	}
+
+	counts := make([]int, maxKey+1)
+	for i, val := range vec {
+		k := key(val)
+		if k < 0 || k > maxKey {
+			panic(fmt.Sprintf("algorithms: CountingSortKeyed key %d at index %d out of range [0, %d]", k, i, maxKey))
+		}
+		counts[k]++
+	}
+
+	for i := 1; i < len(counts); i++ {
+		counts[i] += counts[i-1]
+	}
+
+	sorted := make([]T, len(vec))
+	for i := len(vec) - 1; i >= 0; i-- {
+		k := key(vec[i])
+		counts[k]--
+		sorted[counts[k]] = vec[i]
+	}
+
+	copy(vec, sorted)
+}
+
+// Counting sort for int8, one bucket per value. Values are offset by 128 so
+// -128 lands in bucket 0 and 127 in bucket 255, if the bytes were used as is
+// the negative ones (high bit set) would sort after the positive ones. Same
+// result as RadixSort on []int8, but it writes the values straight from the
+// counts without a scratch slice
+func SortInt8(vec []int8) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	var counts [256]int
+	for _, val := range vec {
+		counts[int(val)+128]++
+	}
+
+	k := 0
+	for b, c := range counts {
+		for ; c > 0; c-- {
+			vec[k] = int8(b - 128)
+			k++
+		}
+	}
+}
+
+// Radix sort for float32 on the IEEE-754 bits. Flipping the sign bit of
+// positive numbers and every bit of negative numbers turns the bits into
+// unsigned ints that sort in the same order as the floats. -0 ends up right
+// before +0 and every NaN goes to the very end, in their original order
+func Float32RadixSort(vec []float32) {
+	if len(vec) <= 1 {
+		return
+	}
+
+	radixSortBytes(vec, 4, func(f float32) uint64 {
+		if f != f {
+			return math.MaxUint32
+		}
+
+		b := math.Float32bits(f)
+		if b&(1<<31) != 0 {
+			return uint64(^b)
+		}
+		return uint64(b | 1<<31)
+	})
+}
@@ -0,0 +1,284 @@
+package algorithms
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCountingSortKeyed(t *testing.T) {
+	key := func(v keyed) int { return v.Key }
+	rng := rand.New(rand.NewSource(1))
+
+	tests := []struct {
+		name   string
+		vec    []keyed
+		maxKey int
+	}{
+		{"empty", nil, 0},
+		{"single", []keyed{{3, 0}}, 5},
+		{"one key", keyedInts(100, 1, rng), 0},
+		{"few categories", keyedInts(1000, 5, rng), 4},
+		{"maxKey unused", keyedInts(1000, 5, rng), 100},
+		{"many categories", keyedInts(5000, 1000, rng), 999},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			CountingSortKeyed(vec, key, tt.maxKey)
+
+			want := slices.Clone(tt.vec)
+			slices.SortStableFunc(want, func(a, b keyed) int { return cmp.Compare(a.Key, b.Key) })
+			if !slices.Equal(vec, want) {
+				t.Fatalf("CountingSortKeyed = %v, want %v", vec, want)
+			}
+			if !CheckStable(tt.vec, vec, key) {
+				t.Errorf("CountingSortKeyed isn't stable")
+			}
+		})
+	}
+}
+
+func TestCountingSortKeyedPanics(t *testing.T) {
+	key := func(v keyed) int { return v.Key }
+
+	tests := []struct {
+		name   string
+		vec    []keyed
+		maxKey int
+		msg    string
+	}{
+		{"negative maxKey", []keyed{{0, 0}, {0, 1}}, -1, "maxKey -1 is negative"},
+		{"negative maxKey empty", nil, -5, "maxKey -5 is negative"},
+		{"key too big", []keyed{{0, 0}, {4, 1}}, 3, "key 4 at index 1 out of range [0, 3]"},
+		{"negative key", []keyed{{-2, 0}, {1, 1}}, 3, "key -2 at index 0 out of range [0, 3]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Fatalf("CountingSortKeyed didn't panic")
+				}
+				if msg := fmt.Sprint(r); !strings.Contains(msg, tt.msg) {
+					t.Errorf("panic %q, want it to say %q", msg, tt.msg)
+				}
+			}()
+			CountingSortKeyed(tt.vec, key, tt.maxKey)
+		})
+	}
+}
+
+// Sorts the boundaries of T mixed with random values of every width bit
+// pattern and compares with slices.Sort
+func testRadixSortType[T Integer](t *testing.T, name string, lo, hi T) {
+	t.Run(name, func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		vec := []T{hi, lo, 0, 1, hi - 1, lo + 1, hi, lo, 0}
+		if lo < 0 {
+			vec = append(vec, ^T(0), ^T(1))
+		}
+		for i := 0; i < 1000; i++ {
+			// truncating a random uint64 hits every bit pattern of T
+			vec = append(vec, T(rng.Uint64()))
+		}
+
+		want := slices.Sorted(slices.Values(vec))
+		RadixSort(vec)
+		if !slices.Equal(vec, want) {
+			t.Errorf("RadixSort[%s] didn't sort", name)
+		}
+		if vec[0] != lo || vec[len(vec)-1] != hi {
+			t.Errorf("RadixSort[%s] ends are %v and %v, want %v and %v", name, vec[0], vec[len(vec)-1], lo, hi)
+		}
+	})
+}
+
+func TestRadixSortTypes(t *testing.T) {
+	testRadixSortType[int8](t, "int8", math.MinInt8, math.MaxInt8)
+	testRadixSortType[int16](t, "int16", math.MinInt16, math.MaxInt16)
+	testRadixSortType[int32](t, "int32", math.MinInt32, math.MaxInt32)
+	testRadixSortType[int64](t, "int64", math.MinInt64, math.MaxInt64)
+	testRadixSortType[int](t, "int", math.MinInt, math.MaxInt)
+	testRadixSortType[uint8](t, "uint8", 0, math.MaxUint8)
+	testRadixSortType[uint16](t, "uint16", 0, math.MaxUint16)
+	testRadixSortType[uint32](t, "uint32", 0, math.MaxUint32)
+	testRadixSortType[uint64](t, "uint64", 0, math.MaxUint64)
+	testRadixSortType[uint](t, "uint", 0, math.MaxUint)
+	testRadixSortType[uintptr](t, "uintptr", 0, ^uintptr(0))
+}
+
+func TestIntegerInfo(t *testing.T) {
+	check := func(name string, width uint, signed bool, wantWidth uint, wantSigned bool) {
+		if width != wantWidth || signed != wantSigned {
+			t.Errorf("integerInfo[%s] = %d, %v, want %d, %v", name, width, signed, wantWidth, wantSigned)
+		}
+	}
+
+	w, s := integerInfo[int8]()
+	check("int8", w, s, 8, true)
+	w, s = integerInfo[uint16]()
+	check("uint16", w, s, 16, false)
+	w, s = integerInfo[int32]()
+	check("int32", w, s, 32, true)
+	w, s = integerInfo[uint64]()
+	check("uint64", w, s, 64, false)
+}
+
+func TestIntRadixSortConfigLastDigits(t *testing.T) {
+	vec := []uint{1234, 99, 5, 7801, 342, 12, 9905, 100, 77, 1212}
+	if err := IntRadixSortConfig(vec, RadixConfig{Base: 10, Passes: 2}); err != nil {
+		t.Fatalf("IntRadixSortConfig = %v", err)
+	}
+
+	// grouped by value % 100, ties in input order
+	want := []uint{100, 7801, 5, 9905, 12, 1212, 1234, 342, 77, 99}
+	if !slices.Equal(vec, want) {
+		t.Errorf("sorted by the last two digits = %v, want %v", vec, want)
+	}
+}
+
+func TestIntRadixSortConfig(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	in := randomUints(5000, 1<<40, rng)
+	in = append(in, 0, ^uint(0), ^uint(0)-1)
+	want := slices.Sorted(slices.Values(in))
+
+	for _, cfg := range []RadixConfig{{}, {Base: 2}, {Base: 10}, {Base: 16, Passes: 16}, {Base: 256}, {Base: MaxRadixBase}, {Base: 7, Passes: 100}} {
+		vec := slices.Clone(in)
+		if err := IntRadixSortConfig(vec, cfg); err != nil {
+			t.Fatalf("%+v: %v", cfg, err)
+		}
+		if !slices.Equal(vec, want) {
+			t.Errorf("%+v didn't sort", cfg)
+		}
+	}
+}
+
+func TestIntRadixSortConfigInvalid(t *testing.T) {
+	for _, cfg := range []RadixConfig{{Base: 1}, {Base: MaxRadixBase + 1}, {Base: 10, Passes: -1}} {
+		vec := []uint{3, 1, 2}
+		if err := IntRadixSortConfig(vec, cfg); err == nil {
+			t.Errorf("IntRadixSortConfig(%+v) didn't fail", cfg)
+		}
+		if !slices.Equal(vec, []uint{3, 1, 2}) {
+			t.Errorf("IntRadixSortConfig(%+v) touched vec before failing", cfg)
+		}
+	}
+}
+
+func TestRadixSortByKey(t *testing.T) {
+	key := func(v keyed) uint64 { return uint64(v.Key) }
+	rng := rand.New(rand.NewSource(1))
+	wide := keyedInts(5000, 1, rng)
+	for i := range wide {
+		// keys needing 1 to 5 byte passes, with some repeats
+		wide[i].Key = rng.Intn(50) << (8 * rng.Intn(5))
+	}
+
+	tests := []struct {
+		name string
+		vec  []keyed
+	}{
+		{"empty", nil},
+		{"single", []keyed{{7, 0}}},
+		{"all key zero", keyedInts(100, 1, rng)},
+		{"few keys", keyedInts(5000, 10, rng)},
+		{"one byte", keyedInts(5000, 256, rng)},
+		{"two bytes", keyedInts(5000, 1<<16, rng)},
+		{"wide", wide},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			RadixSortByKey(vec, key)
+
+			want := slices.Clone(tt.vec)
+			slices.SortStableFunc(want, func(a, b keyed) int { return cmp.Compare(a.Key, b.Key) })
+			if !slices.Equal(vec, want) {
+				t.Fatalf("RadixSortByKey = %v, want %v", vec, want)
+			}
+			if !CheckStable(tt.vec, vec, key) {
+				t.Errorf("RadixSortByKey isn't stable")
+			}
+		})
+	}
+}
+
+func TestFloat32RadixSort(t *testing.T) {
+	inf := float32(math.Inf(1))
+	negZero := float32(math.Copysign(0, -1))
+	nan1, nan2, negNaN := math.Float32frombits(0x7fc00001), math.Float32frombits(0x7fc00002), math.Float32frombits(0xffc00003)
+	subnormal := float32(math.SmallestNonzeroFloat32)
+	minNormal := math.Float32frombits(0x00800000)
+
+	special := []float32{
+		1, nan1, -inf, 0, -math.MaxFloat32, subnormal, negZero, math.MaxFloat32, negNaN,
+		-subnormal, 3 * subnormal, minNormal, -minNormal, minNormal - subnormal, inf, -1, 0.5, nan2, negZero, 0,
+	}
+	rng := rand.New(rand.NewSource(1))
+	random := make([]float32, 5000)
+	for i := range random {
+		// random bit patterns cover every exponent, subnormals included
+		random[i] = math.Float32frombits(rng.Uint32())
+	}
+
+	for name, in := range map[string][]float32{"special": special, "random bits": random} {
+		t.Run(name, func(t *testing.T) {
+			vec := slices.Clone(in)
+			Float32RadixSort(vec)
+
+			// CompareFloat's order, where -0 < +0 and NaN is last, is exact for
+			// float32s converted to float64. SortStableFunc keeps the NaNs in
+			// input order like the radix sort does
+			want := slices.Clone(in)
+			slices.SortStableFunc(want, func(a, b float32) int { return CompareFloat(float64(a), float64(b)) })
+
+			for i := range vec {
+				if math.Float32bits(vec[i]) != math.Float32bits(want[i]) {
+					t.Fatalf("index %d is %v (%#x), want %v (%#x)", i, vec[i], math.Float32bits(vec[i]), want[i], math.Float32bits(want[i]))
+				}
+			}
+		})
+	}
+}
+
+func TestSortInt8(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	every := make([]int8, 256)
+	for i := range every {
+		every[i] = int8(i - 128)
+	}
+	random := make([]int8, 5000)
+	for i := range random {
+		random[i] = int8(rng.Intn(256) - 128)
+	}
+
+	tests := []struct {
+		name string
+		vec  []int8
+	}{
+		{"mixed signs", []int8{3, -1, 127, -128, 0, -50, 50, -1}},
+		// high bit set, as bytes these would sort after the positives
+		{"negatives only", []int8{-1, -128, -2, -127}},
+		{"every value shuffled", func() []int8 { v := slices.Clone(every); Shuffle(v, rng); return v }()},
+		{"random", random},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			SortInt8(vec)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(tt.vec))) {
+				t.Errorf("SortInt8(%v) = %v", tt.vec, vec)
+			}
+		})
+	}
+}
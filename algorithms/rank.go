@@ -0,0 +1,79 @@
+package algorithms
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Indices of vec in the order that would sort it, equal elements keep their
+// original order
+func argSort[T Ordered](vec []T) []int {
+	idx := make([]int, len(vec))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	MergeSortCmp(idx, func(a, b int) int {
+		return cmp.Compare(vec[a], vec[b])
+	})
+	return idx
+}
+
+// 1-based rank of every element of vec in sorted order, at the element's
+// original position. Equal elements all get the average of the ranks they
+// cover, so [10 20 20 30] gives [1 2.5 2.5 4]. vec isn't touched
+func Rank[T Ordered](vec []T) []float64 {
+	idx := argSort(vec)
+	ranks := make([]float64, len(vec))
+
+	for start := 0; start < len(idx); {
+		end := start + 1
+		for end < len(idx) && vec[idx[end]] == vec[idx[start]] {
+			end++
+		}
+
+		// positions start..end-1 are ranks start+1..end
+		avg := float64(start+1+end) / 2
+		for _, i := range idx[start:end] {
+			ranks[i] = avg
+		}
+		start = end
+	}
+
+	return ranks
+}
+
+// Sorted copy of vec plus, for every position of the copy, the index the
+// element had in vec. Equal elements keep their original order. origIndex can
+// be used to put parallel slices in the same order: out[i] = other[origIndex[i]].
+// vec isn't touched
+func SortWithIndices[T Ordered](vec []T) (sorted []T, origIndex []int) {
+	origIndex = argSort(vec)
+	sorted = make([]T, len(vec))
+	for i, idx := range origIndex {
+		sorted[i] = vec[idx]
+	}
+	return sorted, origIndex
+}
+
+// Sorts vec by keys, where keys[i] is the key of vec[i]. Both slices are
+// reordered the same way so they stay aligned, and elements with equal keys
+// keep their order. Handy when the keys were computed already, a comparator
+// would have to compute them again on every comparison. Panics if the
+// lengths differ
+func SortByParallelKeys[T any, K Ordered](vec []T, keys []K) {
+	if len(vec) != len(keys) {
+		panic(fmt.Sprintf("algorithms: SortByParallelKeys got %d elements but %d keys", len(vec), len(keys)))
+	}
+
+	idx := argSort(keys)
+	sortedVec := make([]T, len(vec))
+	sortedKeys := make([]K, len(keys))
+	for i, j := range idx {
+		sortedVec[i] = vec[j]
+		sortedKeys[i] = keys[j]
+	}
+
+	copy(vec, sortedVec)
+	copy(keys, sortedKeys)
+}
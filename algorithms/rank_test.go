@@ -0,0 +1,122 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []int
+		want []float64
+	}{
+		{"example", []int{10, 20, 20, 30}, []float64{1, 2.5, 2.5, 4}},
+		{"empty", nil, []float64{}},
+		{"single", []int{5}, []float64{1}},
+		{"unsorted", []int{30, 10, 20}, []float64{3, 1, 2}},
+		{"all tied", []int{7, 7, 7}, []float64{2, 2, 2}},
+		{"three way tie", []int{5, 1, 5, 5, 9}, []float64{3, 1, 3, 3, 5}},
+		{"two ties", []int{2, 1, 2, 1}, []float64{3.5, 1.5, 3.5, 1.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := slices.Clone(tt.vec)
+			got := Rank(in)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Rank(%v) = %v, want %v", tt.vec, got, tt.want)
+			}
+			if !slices.Equal(in, tt.vec) {
+				t.Errorf("Rank changed its input")
+			}
+		})
+	}
+}
+
+func TestSortWithIndices(t *testing.T) {
+	tests := []struct {
+		name      string
+		vec       []int
+		wantIndex []int
+	}{
+		{"example", []int{30, 10, 20}, []int{1, 2, 0}},
+		{"empty", nil, []int{}},
+		{"duplicates keep their order", []int{2, 1, 2, 1, 2}, []int{1, 3, 0, 2, 4}},
+		{"all equal", []int{4, 4, 4}, []int{0, 1, 2}},
+		{"reversed", []int{3, 2, 1, 0}, []int{3, 2, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := slices.Clone(tt.vec)
+			sorted, idx := SortWithIndices(in)
+			if !slices.Equal(idx, tt.wantIndex) {
+				t.Errorf("SortWithIndices(%v) indices = %v, want %v", tt.vec, idx, tt.wantIndex)
+			}
+			if !slices.Equal(sorted, slices.Sorted(slices.Values(tt.vec))) {
+				t.Errorf("SortWithIndices(%v) = %v, not sorted", tt.vec, sorted)
+			}
+			if !slices.Equal(in, tt.vec) {
+				t.Errorf("SortWithIndices changed its input")
+			}
+		})
+	}
+}
+
+// Reordering a parallel slice by the indices keeps every payload next to its
+// value
+func TestSortWithIndicesReordersPayload(t *testing.T) {
+	ages := []int{41, 36, 85, 36}
+	names := []string{"Alan", "Ada", "Grace", "Anne"}
+
+	sorted, idx := SortWithIndices(ages)
+	reordered := make([]string, len(names))
+	for i, j := range idx {
+		reordered[i] = names[j]
+	}
+
+	if !slices.Equal(sorted, []int{36, 36, 41, 85}) || !slices.Equal(reordered, []string{"Ada", "Anne", "Alan", "Grace"}) {
+		t.Errorf("SortWithIndices reordered to %v %q", sorted, reordered)
+	}
+}
+
+func TestSortByParallelKeys(t *testing.T) {
+	names := []string{"Alan", "Ada", "Grace", "Anne", "Barbara"}
+	ages := []int{41, 36, 85, 36, 41}
+
+	SortByParallelKeys(names, ages)
+	if !slices.Equal(ages, []int{36, 36, 41, 41, 85}) || !slices.Equal(names, []string{"Ada", "Anne", "Alan", "Barbara", "Grace"}) {
+		t.Errorf("SortByParallelKeys reordered to %q %v", names, ages)
+	}
+
+	// every payload still sits next to its own key
+	rng := rand.New(rand.NewSource(1))
+	keys := FewUniqueInts(1000, rng)
+	vec := make([]keyed, len(keys))
+	for i, k := range keys {
+		vec[i] = keyed{k, i}
+	}
+	want := slices.Clone(vec)
+	slices.SortStableFunc(want, func(a, b keyed) int { return a.Key - b.Key })
+
+	SortByParallelKeys(vec, keys)
+	if !slices.Equal(vec, want) {
+		t.Fatalf("SortByParallelKeys isn't a stable sort by key")
+	}
+	for i := range vec {
+		if vec[i].Key != keys[i] {
+			t.Fatalf("payload %v ended up next to key %d", vec[i], keys[i])
+		}
+	}
+}
+
+func TestSortByParallelKeysPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SortByParallelKeys with mismatched lengths didn't panic")
+		}
+	}()
+	SortByParallelKeys([]string{"a", "b"}, []int{1})
+}
@@ -0,0 +1,76 @@
+package algorithms
+
+import "math/rand"
+
+const (
+	// Number of splitters SampleSort aims for, the data is split into one
+	// bucket more than that
+	SampleSortSplitters = 63
+	// How many sample elements are drawn per splitter
+	SampleSortOversampling = 8
+	// Below this many elements SampleSort just runs PDQSort
+	SampleSortThreshold = 1 << 12
+)
+
+// Splits vec into buckets in one pass and sorts every bucket on its own. The
+// splitters come from a sorted random sample, so the buckets end up roughly
+// equally sized whatever the distribution is. Values that are themselves
+// splitters get a bucket of their own that needs no sorting, so duplicate
+// heavy input doesn't create one giant bucket of equal values. The values
+// that aren't splitters can still come in lots of copies, so the buckets are
+// sorted with PDQSort and not QuickSort, whose partition is quadratic on them
+func SampleSort[T Ordered](vec []T) {
+	n := len(vec)
+	if n < SampleSortThreshold {
+		PDQSort(vec)
+		return
+	}
+
+	// fixed seed so the same input always gets the same buckets
+	rng := rand.New(rand.NewSource(1))
+	sample := make([]T, SampleSortSplitters*SampleSortOversampling)
+	for i := range sample {
+		sample[i] = vec[rng.Intn(n)]
+	}
+	IntroSort(sample)
+
+	splitters := make([]T, 0, SampleSortSplitters)
+	for i := 1; i <= SampleSortSplitters; i++ {
+		splitters = append(splitters, sample[i*SampleSortOversampling-1])
+	}
+	splitters = SortUnique(splitters)
+
+	// bucket 2i is for values between splitters i-1 and i, bucket 2i+1 for
+	// values equal to splitter i
+	numBuckets := 2*len(splitters) + 1
+	bucketOf := make([]int, n)
+	counts := make([]int, numBuckets+1)
+
+	for i, val := range vec {
+		s := LowerBound(splitters, val)
+		bucket := 2 * s
+		if s < len(splitters) && splitters[s] == val {
+			bucket++
+		}
+		bucketOf[i] = bucket
+		counts[bucket+1]++
+	}
+
+	for i := 1; i < len(counts); i++ {
+		counts[i] += counts[i-1]
+	}
+	// counts[b] is where bucket b starts, keep a copy for sorting later
+	starts := make([]int, len(counts))
+	copy(starts, counts)
+
+	output := make([]T, n)
+	for i, val := range vec {
+		output[counts[bucketOf[i]]] = val
+		counts[bucketOf[i]]++
+	}
+	copy(vec, output)
+
+	for b := 0; b < numBuckets; b += 2 {
+		PDQSort(vec[starts[b]:starts[b+1]])
+	}
+}
@@ -0,0 +1,116 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSampleSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 200_000
+	zipf := rand.NewZipf(rng, 1.5, 1, 1<<20)
+
+	tests := []struct {
+		name string
+		gen  func(i int) int
+	}{
+		{"random", func(int) int { return rng.Int() }},
+		{"sorted", func(i int) int { return i }},
+		{"reverse", func(i int) int { return n - i }},
+		{"all equal", func(int) int { return 7 }},
+		{"two values", func(int) int { return rng.Intn(2) }},
+		{"few unique", func(int) int { return rng.Intn(FewUniqueValues) }},
+		// more values than splitters, so most of them share a bucket with
+		// thousands of copies of themselves and never get an equal bucket
+		{"100 distinct", func(int) int { return rng.Intn(100) }},
+		{"zipf", func(int) int { return int(zipf.Uint64()) }},
+		{"exponential", func(int) int { return int(rng.ExpFloat64() * 1000) }},
+		{"squared", func(int) int { return int(math.Pow(rng.Float64(), 8) * 1e6) }},
+		{"one outlier", func(i int) int {
+			if i == n/2 {
+				return math.MaxInt
+			}
+			return rng.Intn(1000)
+		}},
+		{"half one value", func(int) int {
+			if rng.Intn(2) == 0 {
+				return 42
+			}
+			return rng.Int()
+		}},
+		{"organ pipe", func(i int) int { return min(i, n-i) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := make([]int, n)
+			for i := range vec {
+				vec[i] = tt.gen(i)
+			}
+			want := slices.Sorted(slices.Values(vec))
+
+			SampleSort(vec)
+			if !slices.Equal(vec, want) {
+				t.Errorf("SampleSort didn't sort %s input", tt.name)
+			}
+		})
+	}
+}
+
+// Right at and around SampleSortThreshold, where it switches from a plain
+// QuickSort to buckets
+func TestSampleSortSizes(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 100, SampleSortThreshold - 1, SampleSortThreshold, SampleSortThreshold + 1, 3 * SampleSortThreshold} {
+		vec := RandomInts(n, rng)
+		want := slices.Sorted(slices.Values(vec))
+		SampleSort(vec)
+		if !slices.Equal(vec, want) {
+			t.Errorf("n=%d: SampleSort didn't sort", n)
+		}
+	}
+}
+
+// The 50M run takes a while and needs a couple of gigabytes, pick it with
+// -bench 'SampleSort/.*/50M'
+func BenchmarkSampleSort(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"SampleSort", SampleSort[int]},
+		{"QuickSort", QuickSort[int]},
+		{"slices.Sort", slices.Sort[[]int]},
+	}
+	sizes := []struct {
+		name string
+		n    int
+	}{
+		{"64K", 1 << 16},
+		{"1M", 1 << 20},
+		{"50M", 50_000_000},
+	}
+
+	for _, size := range sizes {
+		var input []int
+		for _, bm := range benchmarks {
+			b.Run(bm.name+"/"+size.name, func(b *testing.B) {
+				if input == nil {
+					rng := rand.New(rand.NewSource(BenchmarkSeed))
+					input = make([]int, size.n)
+					for i := range input {
+						input[i] = rng.Int()
+					}
+				}
+				vec := make([]int, len(input))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					copy(vec, input)
+					bm.sort(vec)
+				}
+			})
+		}
+	}
+}
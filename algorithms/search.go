@@ -0,0 +1,74 @@
+package algorithms
+
+// Binary searches for slices that are already sorted in ascending order.
+// All of them are O(log n) and return 0 on an empty slice.
+
+// First index i with vec[i] >= target, len(vec) if there is none
+func LowerBound[T Ordered](vec []T, target T) int {
+	lo, hi := 0, len(vec)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if vec[mid] < target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// First index i with vec[i] > target, len(vec) if there is none
+func UpperBound[T Ordered](vec []T, target T) int {
+	lo, hi := 0, len(vec)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if vec[mid] <= target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// Index of the first element equal to target and true, or where target would
+// be inserted and false if it isn't in vec
+func BinarySearch[T Ordered](vec []T, target T) (int, bool) {
+	i := LowerBound(vec, target)
+	return i, i < len(vec) && vec[i] == target
+}
+
+// The Func versions take a comparator like slices.BinarySearchFunc does:
+// cmp(elem, target) is negative if elem goes before target, zero if they are
+// equal and positive if elem goes after it. vec has to be sorted by cmp.
+
+func LowerBoundFunc[T any, K any](vec []T, target K, cmp func(T, K) int) int {
+	lo, hi := 0, len(vec)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if cmp(vec[mid], target) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+func UpperBoundFunc[T any, K any](vec []T, target K, cmp func(T, K) int) int {
+	lo, hi := 0, len(vec)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if cmp(vec[mid], target) <= 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+func BinarySearchFunc[T any, K any](vec []T, target K, cmp func(T, K) int) (int, bool) {
+	i := LowerBoundFunc(vec, target, cmp)
+	return i, i < len(vec) && cmp(vec[i], target) == 0
+}
@@ -0,0 +1,76 @@
+package algorithms
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestBounds(t *testing.T) {
+	vec := []int{1, 3, 3, 3, 5, 7, 7, 9}
+
+	tests := []struct {
+		name         string
+		vec          []int
+		target       int
+		lower, upper int
+		found        bool
+	}{
+		{"empty", nil, 5, 0, 0, false},
+		{"smaller than all", vec, 0, 0, 0, false},
+		{"larger than all", vec, 10, 8, 8, false},
+		{"first", vec, 1, 0, 1, true},
+		{"last", vec, 9, 7, 8, true},
+		{"duplicates", vec, 3, 1, 4, true},
+		{"duplicates at the end", vec, 7, 5, 7, true},
+		{"absent in the middle", vec, 4, 4, 4, false},
+		{"absent between duplicates", vec, 6, 5, 5, false},
+		{"single present", []int{4}, 4, 0, 1, true},
+		{"single absent", []int{4}, 2, 0, 0, false},
+		{"all equal", []int{2, 2, 2}, 2, 0, 3, true},
+	}
+
+	byValue := func(elem, target int) int { return cmp.Compare(elem, target) }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LowerBound(tt.vec, tt.target); got != tt.lower {
+				t.Errorf("LowerBound = %d, want %d", got, tt.lower)
+			}
+			if got := UpperBound(tt.vec, tt.target); got != tt.upper {
+				t.Errorf("UpperBound = %d, want %d", got, tt.upper)
+			}
+			if got, found := BinarySearch(tt.vec, tt.target); got != tt.lower || found != tt.found {
+				t.Errorf("BinarySearch = %d, %v, want %d, %v", got, found, tt.lower, tt.found)
+			}
+
+			if got := LowerBoundFunc(tt.vec, tt.target, byValue); got != tt.lower {
+				t.Errorf("LowerBoundFunc = %d, want %d", got, tt.lower)
+			}
+			if got := UpperBoundFunc(tt.vec, tt.target, byValue); got != tt.upper {
+				t.Errorf("UpperBoundFunc = %d, want %d", got, tt.upper)
+			}
+			if got, found := BinarySearchFunc(tt.vec, tt.target, byValue); got != tt.lower || found != tt.found {
+				t.Errorf("BinarySearchFunc = %d, %v, want %d, %v", got, found, tt.lower, tt.found)
+			}
+		})
+	}
+}
+
+// The Func versions can search by a key of a different type than the elements
+func TestBinarySearchFuncByKey(t *testing.T) {
+	type person struct {
+		Name string
+		Age  int
+	}
+	people := []person{{"ann", 20}, {"bob", 31}, {"cid", 31}, {"dee", 45}}
+	byAge := func(p person, age int) int { return cmp.Compare(p.Age, age) }
+
+	if i, found := BinarySearchFunc(people, 31, byAge); i != 1 || !found {
+		t.Errorf("BinarySearchFunc(31) = %d, %v, want 1, true", i, found)
+	}
+	if got := UpperBoundFunc(people, 31, byAge); got != 3 {
+		t.Errorf("UpperBoundFunc(31) = %d, want 3", got)
+	}
+	if i, found := BinarySearchFunc(people, 40, byAge); i != 3 || found {
+		t.Errorf("BinarySearchFunc(40) = %d, %v, want 3, false", i, found)
+	}
+}
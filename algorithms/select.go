@@ -0,0 +1,313 @@
+package algorithms
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// Rearranges vec so vec[k] holds the element that would be there if vec was
+// sorted, everything before it is <= vec[k] and everything after it is
+// >= vec[k]. Neither side is sorted. k is 0-indexed and it panics if it is
+// out of range.
+//
+// Duplicates of vec[k] can end up on both sides of it, that is fine since
+// they are equal. Partitioning is three-way, so lots of duplicates make it
+// faster instead of quadratic. O(n) on average.
+func NthElement[T Ordered](vec []T, k int) {
+	if k < 0 || k >= len(vec) {
+		panic(fmt.Sprintf("algorithms: NthElement index %d out of range for length %d", k, len(vec)))
+	}
+
+	start, end := 0, len(vec)-1
+	for start < end {
+		mid := start + (end-start)/2
+		pivot := vec[medianOfThree(vec, start, mid, end)]
+		lt, gt := partition3(vec, start, end, pivot)
+
+		if k < lt {
+			end = lt - 1
+		} else if k > gt {
+			start = gt + 1
+		} else {
+			// k landed among the elements equal to the pivot
+			return
+		}
+	}
+}
+
+// kth smallest element of vec, 0-indexed. vec is rearranged like NthElement
+// does
+func QuickSelect[T Ordered](vec []T, k int) T {
+	NthElement(vec, k)
+	return vec[k]
+}
+
+// How many elements IntroSelect lets median of three pivots partition, in
+// multiples of len(vec), before it switches to median of medians. If every
+// round halved the range it would take 2*len(vec), so this is twice that
+const IntroSelectBudget = 4
+
+// QuickSelect that can't go quadratic, the same way IntroSort hardens
+// QuickSort. It starts out with median of three pivots and once those have
+// partitioned IntroSelectBudget*n elements without finding k it switches to
+// median of medians pivots, which always throw away at least 30% of the
+// range. O(n) on average like QuickSelect and O(n) in the worst case too. A
+// limit on the number of rounds like IntroSort's wouldn't do, log n rounds
+// that barely shrink the range are already O(n log n). k is 0-indexed, vec
+// is rearranged like NthElement does and it panics if k is out of range
+func IntroSelect[T Ordered](vec []T, k int) T {
+	if k < 0 || k >= len(vec) {
+		panic(fmt.Sprintf("algorithms: IntroSelect index %d out of range for length %d", k, len(vec)))
+	}
+
+	return introSelect(vec, k, IntroSelectBudget*len(vec), cmp.Compare[T])
+}
+
+// IntroSelect with a three-way comparator like the one slices.SortFunc
+// takes. Panics if k is out of range
+func IntroSelectFunc[T any](vec []T, k int, cmp func(a, b T) int) T {
+	if k < 0 || k >= len(vec) {
+		panic(fmt.Sprintf("algorithms: IntroSelectFunc index %d out of range for length %d", k, len(vec)))
+	}
+
+	return introSelect(vec, k, IntroSelectBudget*len(vec), cmp)
+}
+
+// Selection with median of three pivots until they've partitioned budget
+// elements in total, and median of medians pivots after that
+func introSelect[T any](vec []T, k int, budget int, cmp func(a, b T) int) T {
+	start, end := 0, len(vec)-1
+	for start < end {
+		var pivot T
+		if budget > 0 {
+			budget -= end - start + 1
+			pivot = vec[medianOfThreeCmp(vec, start, start+(end-start)/2, end, cmp)]
+		} else {
+			pivot = medianOfMedians(vec, start, end, cmp)
+		}
+
+		lt, gt := partition3Cmp(vec, start, end, pivot, cmp)
+		if k < lt {
+			end = lt - 1
+		} else if k > gt {
+			start = gt + 1
+		} else {
+			break
+		}
+	}
+
+	return vec[k]
+}
+
+// Median of medians of vec[start:end+1]: the median of every group of 5 is
+// moved to the front and the median of those is selected, again with median
+// of medians pivots. Only used as a pivot so it doesn't have to be the real
+// median, it is just guaranteed to be somewhere in the middle 40%
+func medianOfMedians[T any](vec []T, start int, end int, cmp func(a, b T) int) T {
+	if end-start < 5 {
+		InsertionSortCmp(vec[start:end+1], cmp)
+		return vec[start+(end-start)/2]
+	}
+
+	// the leftover group of less than 5 is left out, it doesn't change the
+	// guarantee
+	m := 0
+	for g := start; g+4 <= end; g += 5 {
+		InsertionSortCmp(vec[g:g+5], cmp)
+		vec[start+m], vec[g+2] = vec[g+2], vec[start+m]
+		m++
+	}
+
+	return momSelect(vec, start, start+m-1, start+m/2, cmp)
+}
+
+// Selection of vec[k] inside vec[start:end+1] with only median of medians
+// pivots
+func momSelect[T any](vec []T, start int, end int, k int, cmp func(a, b T) int) T {
+	for start < end {
+		pivot := medianOfMedians(vec, start, end, cmp)
+		lt, gt := partition3Cmp(vec, start, end, pivot, cmp)
+		if k < lt {
+			end = lt - 1
+		} else if k > gt {
+			start = gt + 1
+		} else {
+			break
+		}
+	}
+
+	return vec[k]
+}
+
+// The partition step of QuickSort on vec[lo:hi]. A median of three pivot is
+// picked and moved to its final spot, everything in vec[lo:p] is <= it and
+// everything in vec[p+1:hi] is > it, where p is the returned index. Panics
+// unless 0 <= lo < hi <= len(vec)
+func Partition[T Ordered](vec []T, lo, hi int) int {
+	if lo < 0 || lo >= hi || hi > len(vec) {
+		panic(fmt.Sprintf("algorithms: Partition bounds [%d:%d] invalid for length %d", lo, hi, len(vec)))
+	}
+
+	return partition(vec, lo, hi-1)
+}
+
+// Splits vec into three parts around pivot (pivot doesn't have to be in vec).
+// Afterwards vec[:lt] < pivot, vec[lt:gt] == pivot and vec[gt:] > pivot.
+// Single pass, O(n), not stable
+func Partition3Way[T Ordered](vec []T, pivot T) (lt, gt int) {
+	lt, gt = partition3(vec, 0, len(vec)-1, pivot)
+	return lt, gt + 1
+}
+
+// kth largest element of vec, 0-indexed like QuickSelect, so k == 0 is the
+// max. Duplicates each take up their own rank: in [5 5 3] both k == 0 and
+// k == 1 give 5. Panics if k is out of range
+func QuickSelectLargest[T Ordered](vec []T, k int) T {
+	if k < 0 || k >= len(vec) {
+		panic(fmt.Sprintf("algorithms: QuickSelectLargest index %d out of range for length %d", k, len(vec)))
+	}
+
+	return QuickSelect(vec, len(vec)-1-k)
+}
+
+// Dutch national flag partition of vec[start:end+1] around pivot. Afterwards
+// vec[start:lt] < pivot, vec[lt:gt+1] == pivot and vec[gt+1:end+1] > pivot
+func partition3[T Ordered](vec []T, start int, end int, pivot T) (lt int, gt int) {
+	lt, i, gt := start, start, end
+	for i <= gt {
+		if vec[i] < pivot {
+			vec[lt], vec[i] = vec[i], vec[lt]
+			lt++
+			i++
+		} else if vec[i] > pivot {
+			vec[i], vec[gt] = vec[gt], vec[i]
+			gt--
+		} else {
+			i++
+		}
+	}
+	return lt, gt
+}
+
+// partition3 with a three-way comparator
+func partition3Cmp[T any](vec []T, start int, end int, pivot T, cmp func(a, b T) int) (lt int, gt int) {
+	lt, i, gt := start, start, end
+	for i <= gt {
+		if c := cmp(vec[i], pivot); c < 0 {
+			vec[lt], vec[i] = vec[i], vec[lt]
+			lt++
+			i++
+		} else if c > 0 {
+			vec[i], vec[gt] = vec[gt], vec[i]
+			gt--
+		} else {
+			i++
+		}
+	}
+	return lt, gt
+}
+
+// Puts the n smallest elements of vec in vec[:n], in sorted order.
+// vec[n:] is left in no particular order. n == 0 does nothing and
+// n >= len(vec) just sorts all of vec. Panics if n is negative.
+func SortLimited[T Ordered](vec []T, n int) {
+	if n < 0 {
+		panic(fmt.Sprintf("algorithms: SortLimited called with negative n %d", n))
+	}
+
+	if n >= len(vec) {
+		IntroSort(vec)
+		return
+	}
+
+	if n == 0 {
+		return
+	}
+
+	// everything before index n is now <= vec[n]
+	NthElement(vec, n)
+	IntroSort(vec[:n])
+}
+
+// Value at every percentile in ps, in the same order as ps. Percentiles go
+// from 0 to 100 and use the nearest rank method: the p-th percentile is the
+// smallest element that at least p% of vec is <= to, i.e. the element at
+// rank ceil(p/100 * n) (1-indexed) of the sorted vec, and p == 0 gives the
+// min. There is no interpolation, the result is always an element of vec.
+//
+// A few percentiles are found with one IntroSelect each, every select only
+// looks at the part of vec after the previous rank. Once there are more than
+// log2(n) of them sorting vec is cheaper and it does that instead. Either way
+// vec is reordered. Panics if vec is empty or a percentile isn't in [0, 100]
+func Percentiles[T Ordered](vec []T, ps []float64) []T {
+	if len(vec) == 0 {
+		panic("algorithms: Percentiles of an empty slice")
+	}
+
+	n := len(vec)
+	ranks := make([]int, len(ps))
+	for i, p := range ps {
+		if !(p >= 0 && p <= 100) {
+			panic(fmt.Sprintf("algorithms: Percentiles percentile %v not in [0, 100]", p))
+		}
+		ranks[i] = max(nearestRank(p, n)-1, 0)
+	}
+
+	if len(ps) > bits.Len(uint(n)) {
+		IntroSort(vec)
+	} else {
+		sorted := slices.Clone(ranks)
+		IntroSort(sorted)
+
+		// everything before the last selected rank is <= it and everything
+		// after is >= it, so the next select only has to look to the right
+		start := 0
+		for _, r := range slices.Compact(sorted) {
+			IntroSelect(vec[start:], r-start)
+			start = r + 1
+		}
+	}
+
+	out := make([]T, len(ps))
+	for i, r := range ranks {
+		out[i] = vec[r]
+	}
+	return out
+}
+
+// Moves every element pred is false for in front of the ones it is true for.
+// Both groups keep their order, so it's a stable counting sort with two
+// buckets. One pass and a buffer for the true group, O(n) time and at most
+// O(n) extra memory. Returns where the true group starts
+func StablePartition[T any](vec []T, pred func(T) bool) int {
+	var trues []T
+	k := 0
+	for _, val := range vec {
+		if pred(val) {
+			trues = append(trues, val)
+		} else {
+			vec[k] = val
+			k++
+		}
+	}
+
+	copy(vec[k:], trues)
+	return k
+}
+
+// ceil(p/100 * n) without the rounding error of p/100, e.g. 0.07*100 is a
+// hair above 7 and would round up to 8. Multiplying first is exact for whole
+// percentiles, and anything within a tiny epsilon of a whole number is
+// snapped to it for the rest
+func nearestRank(p float64, n int) int {
+	rank := p * float64(n) / 100
+
+	const epsilon = 1e-9
+	if whole := math.Round(rank); math.Abs(rank-whole) <= epsilon*max(1, whole) {
+		return int(whole)
+	}
+	return int(math.Ceil(rank))
+}
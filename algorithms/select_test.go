@@ -0,0 +1,494 @@
+package algorithms
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPercentiles(t *testing.T) {
+	// 1..100 shuffled, so the p-th percentile is just p
+	hundred := make([]int, 100)
+	for i := range hundred {
+		hundred[i] = i + 1
+	}
+	Shuffle(hundred, rand.New(rand.NewSource(1)))
+
+	tests := []struct {
+		name string
+		vec  []int
+		ps   []float64
+		want []int
+	}{
+		{"p50 p90 p99", hundred, []float64{50, 90, 99}, []int{50, 90, 99}},
+		{"float error prone", hundred, []float64{7, 14, 28, 29, 57, 58}, []int{7, 14, 28, 29, 57, 58}},
+		{"ends", hundred, []float64{0, 100}, []int{1, 100}},
+		{"repeated and unordered", hundred, []float64{90, 10, 90, 10}, []int{90, 10, 90, 10}},
+		{"fractional", hundred, []float64{0.5, 99.5, 12.25}, []int{1, 100, 13}},
+		{"many use a full sort", hundred, []float64{1, 5, 10, 20, 30, 40, 50, 60, 70, 80, 90, 95}, []int{1, 5, 10, 20, 30, 40, 50, 60, 70, 80, 90, 95}},
+		// the classic nearest rank example
+		{"small", []int{15, 20, 35, 40, 50}, []float64{5, 30, 40, 50, 100}, []int{15, 20, 20, 35, 50}},
+		{"single", []int{7}, []float64{0, 50, 100}, []int{7, 7, 7}},
+		{"duplicates", []int{3, 1, 3, 3, 2, 3}, []float64{10, 50, 90}, []int{1, 3, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Percentiles(slices.Clone(tt.vec), tt.ps)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Percentiles(%v) = %v, want %v", tt.ps, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentilesEveryWholePercentile(t *testing.T) {
+	for _, n := range []int{1, 3, 10, 100, 1000, 12345} {
+		vec := SortedInts(n)
+		for p := 0; p <= 100; p++ {
+			// ceil(p*n/100) with integers only
+			rank := max((p*n+99)/100, 1)
+			got := Percentiles(slices.Clone(vec), []float64{float64(p)})
+			if got[0] != rank-1 {
+				t.Fatalf("n=%d p%d = %d, want %d", n, p, got[0], rank-1)
+			}
+		}
+	}
+}
+
+func TestPercentilesPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []int
+		ps   []float64
+	}{
+		{"empty", nil, []float64{50}},
+		{"negative", []int{1}, []float64{-1}},
+		{"too big", []int{1}, []float64{101}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Percentiles(%v, %v) didn't panic", tt.vec, tt.ps)
+				}
+			}()
+			Percentiles(tt.vec, tt.ps)
+		})
+	}
+}
+
+func TestPartition3Way(t *testing.T) {
+	tests := []struct {
+		name   string
+		vec    []int
+		pivot  int
+		lt, gt int
+	}{
+		{"empty", nil, 5, 0, 0},
+		{"all equal", []int{4, 4, 4, 4, 4}, 4, 0, 5},
+		{"no equal", []int{9, 1, 7, 3, 8, 2}, 5, 3, 3},
+		{"all smaller", []int{3, 1, 2}, 10, 3, 3},
+		{"all bigger", []int{3, 1, 2}, 0, 0, 0},
+		{"mixed", []int{5, 1, 9, 5, 3, 7, 5}, 5, 2, 5},
+		{"single equal", []int{5}, 5, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			lt, gt := Partition3Way(vec, tt.pivot)
+			if lt != tt.lt || gt != tt.gt {
+				t.Fatalf("Partition3Way(%v, %d) = %d, %d, want %d, %d", tt.vec, tt.pivot, lt, gt, tt.lt, tt.gt)
+			}
+			checkPartitioned(t, vec, tt.pivot, lt, gt)
+		})
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		vec := FewUniqueInts(200, rng)
+		pivot := rng.Intn(FewUniqueValues + 2)
+		lt, gt := Partition3Way(vec, pivot)
+		checkPartitioned(t, vec, pivot, lt, gt)
+	}
+}
+
+func checkPartitioned(t *testing.T, vec []int, pivot, lt, gt int) {
+	t.Helper()
+	for i, val := range vec {
+		if i < lt && val >= pivot || i >= lt && i < gt && val != pivot || i >= gt && val <= pivot {
+			t.Fatalf("vec[%d] = %d is on the wrong side of %d in %v (lt %d, gt %d)", i, val, pivot, vec, lt, gt)
+		}
+	}
+}
+
+func TestPartition(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		n := rng.Intn(50) + 1
+		in := FewUniqueInts(n, rng)
+		if i%2 == 0 {
+			in = RandomInts(n, rng)
+		}
+		lo := rng.Intn(n)
+		hi := lo + 1 + rng.Intn(n-lo)
+
+		vec := slices.Clone(in)
+		p := Partition(vec, lo, hi)
+		if p < lo || p >= hi {
+			t.Fatalf("Partition(%v, %d, %d) = %d, outside of the range", in, lo, hi, p)
+		}
+		for j := lo; j < hi; j++ {
+			if j < p && vec[j] > vec[p] || j > p && vec[j] <= vec[p] {
+				t.Fatalf("Partition(%v, %d, %d) = %d: %v", in, lo, hi, p, vec)
+			}
+		}
+		if !slices.Equal(vec[:lo], in[:lo]) || !slices.Equal(vec[hi:], in[hi:]) {
+			t.Fatalf("Partition(%d, %d) touched elements outside the range", lo, hi)
+		}
+	}
+}
+
+// Median of three: on sorted input the middle element is the pivot, so it
+// stays where it is
+func TestPartitionMedianOfThree(t *testing.T) {
+	vec := SortedInts(101)
+	if p := Partition(vec, 0, len(vec)); p != 50 {
+		t.Errorf("Partition of sorted input = %d, want the middle 50", p)
+	}
+}
+
+func TestPartitionPanics(t *testing.T) {
+	for _, bounds := range [][2]int{{-1, 3}, {2, 2}, {3, 2}, {0, 6}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Partition(%d, %d) didn't panic", bounds[0], bounds[1])
+				}
+			}()
+			Partition(SortedInts(5), bounds[0], bounds[1])
+		}()
+	}
+}
+
+// vec[k] is what sorting would put there, nothing before it is bigger and
+// nothing after it is smaller, for every k
+func TestNthElement(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	inputs := [][]int{
+		{5},
+		{2, 1},
+		{3, 1, 3, 2, 1, 3},
+		{7, 7, 7, 7, 7},
+		SortedInts(50),
+		ReverseSortedInts(50),
+		FewUniqueInts(200, rng),
+		RandomInts(200, rng),
+	}
+
+	for _, in := range inputs {
+		want := slices.Sorted(slices.Values(in))
+		for k := range in {
+			vec := slices.Clone(in)
+			NthElement(vec, k)
+			if vec[k] != want[k] {
+				t.Fatalf("NthElement(%v, %d) put %d there, want %d", in, k, vec[k], want[k])
+			}
+			for i, val := range vec {
+				if i < k && val > vec[k] || i > k && val < vec[k] {
+					t.Fatalf("NthElement(%v, %d): vec[%d] = %d is on the wrong side of %d", in, k, i, val, vec[k])
+				}
+			}
+			if !slices.Equal(slices.Sorted(slices.Values(vec)), want) {
+				t.Fatalf("NthElement(%v, %d) changed the elements", in, k)
+			}
+
+			if got := QuickSelect(slices.Clone(in), k); got != want[k] {
+				t.Fatalf("QuickSelect(%v, %d) = %d, want %d", in, k, got, want[k])
+			}
+		}
+	}
+}
+
+func TestNthElementPanics(t *testing.T) {
+	sels := map[string]func(vec []int, k int){
+		"NthElement":  NthElement[int],
+		"QuickSelect": func(vec []int, k int) { QuickSelect(vec, k) },
+	}
+
+	for name, sel := range sels {
+		for _, tt := range []struct {
+			vec []int
+			k   int
+		}{{[]int{1, 2, 3}, -1}, {[]int{1, 2, 3}, 3}, {nil, 0}} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s(%v, %d) didn't panic", name, tt.vec, tt.k)
+					}
+				}()
+				sel(tt.vec, tt.k)
+			}()
+		}
+	}
+}
+
+func TestSortLimited(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, in := range [][]int{{3, 1, 2}, {4, 4, 1, 4, 1}, ReverseSortedInts(100), FewUniqueInts(300, rng), RandomInts(300, rng)} {
+		want := slices.Sorted(slices.Values(in))
+		for _, n := range []int{0, 1, 2, len(in) / 2, len(in) - 1, len(in), len(in) + 5} {
+			vec := slices.Clone(in)
+			SortLimited(vec, n)
+
+			m := min(n, len(in))
+			if !slices.Equal(vec[:m], want[:m]) {
+				t.Fatalf("SortLimited(%v, %d) front = %v, want %v", in, n, vec[:m], want[:m])
+			}
+			// the rest is only a permutation of what's left
+			if !slices.Equal(slices.Sorted(slices.Values(vec[m:])), want[m:]) {
+				t.Fatalf("SortLimited(%v, %d) lost elements past n", in, n)
+			}
+			if n == 0 && !slices.Equal(vec, in) {
+				t.Fatalf("SortLimited with n=0 changed vec")
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SortLimited with a negative n didn't panic")
+		}
+	}()
+	SortLimited([]int{1, 2}, -1)
+}
+
+func TestQuickSelectLargest(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []int
+		k    int
+		want int
+	}{
+		{"max", []int{3, 9, 1, 7}, 0, 9},
+		{"min", []int{3, 9, 1, 7}, 3, 1},
+		{"second", []int{3, 9, 1, 7}, 1, 7},
+		// every copy takes its own rank
+		{"duplicate max first", []int{5, 5, 3}, 0, 5},
+		{"duplicate max second", []int{5, 5, 3}, 1, 5},
+		{"past the duplicates", []int{5, 5, 3}, 2, 3},
+		{"duplicates at the boundary", []int{1, 8, 4, 8, 4, 4, 2}, 1, 8},
+		{"duplicates just past it", []int{1, 8, 4, 8, 4, 4, 2}, 2, 4},
+		{"inside the duplicates", []int{1, 8, 4, 8, 4, 4, 2}, 4, 4},
+		{"last duplicate", []int{1, 8, 4, 8, 4, 4, 2}, 5, 2},
+		{"all equal", []int{6, 6, 6}, 2, 6},
+		{"single", []int{4}, 0, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QuickSelectLargest(slices.Clone(tt.vec), tt.k); got != tt.want {
+				t.Errorf("QuickSelectLargest(%v, %d) = %d, want %d", tt.vec, tt.k, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuickSelectLargestPanics(t *testing.T) {
+	for _, k := range []int{-1, 3, 100} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("QuickSelectLargest(k=%d) didn't panic", k)
+				}
+			}()
+			QuickSelectLargest([]int{1, 2, 3}, k)
+		}()
+	}
+}
+
+// Inputs that make simple pivot choices pick badly, every k on small sizes
+func TestIntroSelect(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	patterns := map[string]func(n int) []int{
+		"random":     func(n int) []int { return RandomInts(n, rng) },
+		"few unique": func(n int) []int { return FewUniqueInts(n, rng) },
+		"sorted":     SortedInts,
+		"reverse":    ReverseSortedInts,
+		"organ pipe": func(n int) []int {
+			vec := make([]int, n)
+			for i := range vec {
+				vec[i] = min(i, n-i)
+			}
+			return vec
+		},
+		"all equal": func(n int) []int { return make([]int, n) },
+	}
+
+	for name, gen := range patterns {
+		for _, n := range []int{1, 2, 5, 6, 17, 100} {
+			in := gen(n)
+			want := slices.Sorted(slices.Values(in))
+			for k := 0; k < n; k++ {
+				if got := IntroSelect(slices.Clone(in), k); got != want[k] {
+					t.Fatalf("%s: IntroSelect(%v, %d) = %d, want %d", name, in, k, got, want[k])
+				}
+				// the median of medians fallback on its own
+				if got := momSelect(slices.Clone(in), 0, n-1, k, cmp.Compare[int]); got != want[k] {
+					t.Fatalf("%s: momSelect(%v, %d) = %d, want %d", name, in, k, got, want[k])
+				}
+			}
+		}
+	}
+}
+
+func TestIntroSelectPanics(t *testing.T) {
+	for _, k := range []int{-1, 3} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("IntroSelect(k=%d) didn't panic", k)
+				}
+			}()
+			IntroSelect([]int{1, 2, 3}, k)
+		}()
+	}
+}
+
+// McIlroy's adversary from "A Killer Adversary for Quicksort", run against
+// introSelect looking for k with median of three pivots only, the budget
+// never runs out. Every element starts out as gas, bigger than anything
+// solid, and only gets a value when it's compared with another gas element.
+// The pivots come out as the smallest of what's left, so every round only
+// gets rid of a couple of elements. The values handed out, with the gas left
+// at n, are an input that does exactly the same to median of three pivots
+// every time
+func medianOfThreeKiller(n, k int) []int {
+	gas := n
+	val := make([]int, n)
+	for i := range val {
+		val[i] = gas
+	}
+
+	solid, candidate := 0, 0
+	freeze := func(i int) {
+		val[i] = solid
+		solid++
+	}
+	introSelect(SortedInts(n), k, n*n, func(a, b int) int {
+		if val[a] == gas && val[b] == gas {
+			if a == candidate {
+				freeze(a)
+			} else {
+				freeze(b)
+			}
+		}
+		if val[a] == gas {
+			candidate = a
+		} else if val[b] == gas {
+			candidate = b
+		}
+		return cmp.Compare(val[a], val[b])
+	})
+	return val
+}
+
+// On the killer, median of three pivots alone go quadratic, and once
+// IntroSelect switches to median of medians it stays linear
+func TestIntroSelectKiller(t *testing.T) {
+	for _, n := range []int{1000, 10_000} {
+		k := n / 2
+		in := medianOfThreeKiller(n, k)
+		want := slices.Sorted(slices.Values(in))[k]
+
+		comparisons := 0
+		counting := func(a, b int) int {
+			comparisons++
+			return cmp.Compare(a, b)
+		}
+
+		if got := introSelect(slices.Clone(in), k, n*n, counting); got != want {
+			t.Fatalf("n=%d: median of three only = %d, want %d", n, got, want)
+		}
+		if comparisons < n*n/16 {
+			t.Fatalf("n=%d: median of three only took %d comparisons, the killer should make it quadratic", n, comparisons)
+		}
+
+		comparisons = 0
+		if got := IntroSelectFunc(slices.Clone(in), k, counting); got != want {
+			t.Fatalf("n=%d: IntroSelectFunc = %d, want %d", n, got, want)
+		}
+		if comparisons > 20*n {
+			t.Errorf("n=%d: IntroSelectFunc took %d comparisons, want at most %d", n, comparisons, 20*n)
+		}
+
+		if got := IntroSelect(slices.Clone(in), k); got != want {
+			t.Errorf("n=%d: IntroSelect = %d, want %d", n, got, want)
+		}
+	}
+}
+
+// Median of medians on its own is linear on anything, sorted, reversed or
+// all equal included
+func TestMomSelectIsLinear(t *testing.T) {
+	const n = 100_000
+	inputs := map[string][]int{
+		"random":    RandomInts(n, rand.New(rand.NewSource(1))),
+		"sorted":    SortedInts(n),
+		"reverse":   ReverseSortedInts(n),
+		"all equal": make([]int, n),
+	}
+
+	for name, in := range inputs {
+		comparisons := 0
+		counting := func(a, b int) int {
+			comparisons++
+			return cmp.Compare(a, b)
+		}
+
+		want := slices.Sorted(slices.Values(in))[n/2]
+		if got := momSelect(slices.Clone(in), 0, n-1, n/2, counting); got != want {
+			t.Fatalf("%s: momSelect = %d, want %d", name, got, want)
+		}
+		if comparisons > 20*n {
+			t.Errorf("%s: momSelect took %d comparisons, want at most %d", name, comparisons, 20*n)
+		}
+	}
+}
+
+func TestStablePartition(t *testing.T) {
+	odd := func(v keyed) bool { return v.Key%2 != 0 }
+	rng := rand.New(rand.NewSource(1))
+
+	for _, in := range [][]keyed{
+		nil,
+		{{1, 0}},
+		{{2, 0}},
+		{{1, 0}, {2, 1}, {3, 2}, {4, 3}, {5, 4}},
+		{{2, 0}, {4, 1}, {6, 2}},
+		{{1, 0}, {3, 1}, {5, 2}},
+		keyedInts(1000, 10, rng),
+	} {
+		var wantFalse, wantTrue []keyed
+		for _, v := range in {
+			if odd(v) {
+				wantTrue = append(wantTrue, v)
+			} else {
+				wantFalse = append(wantFalse, v)
+			}
+		}
+
+		vec := slices.Clone(in)
+		k := StablePartition(vec, odd)
+		if k != len(wantFalse) {
+			t.Fatalf("StablePartition(%v) = %d, want %d", in, k, len(wantFalse))
+		}
+		// both groups in their input order, positions tell equal keys apart
+		if !slices.Equal(vec[:k], wantFalse) || !slices.Equal(vec[k:], wantTrue) {
+			t.Fatalf("StablePartition(%v) left %v", in, vec)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package algorithms
+
+// Sorts vec but treats every element equal to sentinel as "missing": they all
+// go to the end if last is true, to the front otherwise, no matter where the
+// sentinel value would sort normally. The other elements are sorted as usual.
+//
+// Elements are matched with ==, so NaN can't be used as a sentinel for floats.
+// SortFloatsChecked or Float32RadixSort already deal with NaNs.
+func SortWithSentinel[T Ordered](vec []T, sentinel T, last bool) {
+	// Move the non sentinels to one side in one pass. Sentinels are all equal
+	// so it doesn't matter that this shuffles them around
+	var rest []T
+	if last {
+		n := 0
+		for i := range vec {
+			if vec[i] != sentinel {
+				vec[n], vec[i] = vec[i], vec[n]
+				n++
+			}
+		}
+		rest = vec[:n]
+	} else {
+		n := len(vec)
+		for i := len(vec) - 1; i >= 0; i-- {
+			if vec[i] != sentinel {
+				n--
+				vec[n], vec[i] = vec[i], vec[n]
+			}
+		}
+		rest = vec[n:]
+	}
+
+	IntroSort(rest)
+}
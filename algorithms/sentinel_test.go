@@ -0,0 +1,36 @@
+package algorithms
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSortWithSentinel(t *testing.T) {
+	tests := []struct {
+		name     string
+		vec      []int
+		sentinel int
+		last     bool
+		want     []int
+	}{
+		{"last", []int{3, -1, 1, -1, 2, -1}, -1, true, []int{1, 2, 3, -1, -1, -1}},
+		{"first", []int{3, -1, 1, -1, 2, -1}, -1, false, []int{-1, -1, -1, 1, 2, 3}},
+		{"max to the front", []int{5, math.MaxInt, 0, math.MaxInt, -5}, math.MaxInt, false, []int{math.MaxInt, math.MaxInt, -5, 0, 5}},
+		{"min to the end", []int{5, math.MinInt, 0, -5}, math.MinInt, true, []int{-5, 0, 5, math.MinInt}},
+		{"no sentinels", []int{3, 1, 2}, -1, true, []int{1, 2, 3}},
+		{"only sentinels", []int{-1, -1}, -1, false, []int{-1, -1}},
+		{"sentinel in the middle of the range", []int{4, 0, 2, 0, 1}, 2, true, []int{0, 0, 1, 4, 2}},
+		{"empty", nil, 0, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			SortWithSentinel(vec, tt.sentinel, tt.last)
+			if !slices.Equal(vec, tt.want) {
+				t.Errorf("SortWithSentinel(%v, %d, %v) = %v, want %v", tt.vec, tt.sentinel, tt.last, vec, tt.want)
+			}
+		})
+	}
+}
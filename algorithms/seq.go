@@ -0,0 +1,56 @@
+package algorithms
+
+import (
+	"iter"
+	"slices"
+)
+
+// Returns an iterator over the elements of seq in sorted order. seq is only
+// read once the returned iterator is ranged over, and it is read fully since
+// the smallest element could be the last one. Breaking out of the range loop
+// just stops yielding, there is nothing running in the background.
+func SortSeq[T Ordered](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		vec := slices.Collect(seq)
+		IntroSort(vec)
+		for _, val := range vec {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// Same as SortSeq but ordered by cmp. Stable, equal elements come out in the
+// order seq produced them
+func SortSeqFunc[T any](seq iter.Seq[T], cmp func(a, b T) int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		vec := slices.Collect(seq)
+		MergeSortCmp(vec, cmp)
+		for _, val := range vec {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// Yields the elements of vec in sorted order without sorting all of it up
+// front. Ranging over it turns vec into a min-heap in O(n) and then pops one
+// element per step in O(log n), so a loop that breaks after k elements costs
+// O(n + k log n) instead of a full sort. vec is used as the heap and is left
+// in a heap-ish order, popped elements pile up at the back (a full range
+// leaves vec sorted in descending order). Ranging again starts over with
+// whatever vec holds at that point
+func SortedIter[T Ordered](vec []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		BuildMinHeap(vec)
+		for n := len(vec); n > 0; n-- {
+			vec[0], vec[n-1] = vec[n-1], vec[0]
+			MinHeapify(vec, 0, n-1)
+			if !yield(vec[n-1]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package algorithms
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSortSeq(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, in := range [][]int{nil, {1}, {3, 1, 2}, {2, 2, 1, 2}, RandomInts(1000, rng)} {
+		got := slices.Collect(SortSeq(slices.Values(in)))
+		if want := slices.Sorted(slices.Values(in)); !slices.Equal(got, want) {
+			t.Errorf("SortSeq(%v) = %v, want %v", in, got, want)
+		}
+	}
+
+	// the seq isn't read until the range starts
+	read := false
+	seq := SortSeq(func(yield func(int) bool) {
+		read = true
+		yield(1)
+	})
+	if read {
+		t.Errorf("SortSeq read the seq before it was ranged over")
+	}
+	for range seq {
+	}
+	if !read {
+		t.Errorf("ranging over SortSeq didn't read the seq")
+	}
+}
+
+// Equal keys come out in the order the seq produced them
+func TestSortSeqFuncStable(t *testing.T) {
+	in := keyedInts(1000, 10, rand.New(rand.NewSource(1)))
+	key := func(v keyed) int { return v.Key }
+
+	got := slices.Collect(SortSeqFunc(slices.Values(in), ByField(key)))
+	want := slices.Clone(in)
+	slices.SortStableFunc(want, ByField(key))
+	if !slices.Equal(got, want) {
+		t.Errorf("SortSeqFunc isn't a stable sort by key")
+	}
+	if !CheckStable(in, got, key) {
+		t.Errorf("SortSeqFunc isn't stable")
+	}
+}
+
+// Breaking out of the range just stops yielding, no panic from yielding
+// after yield returned false, and ranging again starts over
+func TestSortSeqBreak(t *testing.T) {
+	in := RandomInts(100, rand.New(rand.NewSource(1)))
+	want := slices.Sorted(slices.Values(in))
+
+	seqs := map[string]iter.Seq[int]{
+		"SortSeq":     SortSeq(slices.Values(in)),
+		"SortSeqFunc": SortSeqFunc(slices.Values(in), ByField(func(x int) int { return x })),
+	}
+
+	for name, seq := range seqs {
+		for _, k := range []int{1, 3, 100} {
+			var got []int
+			for val := range seq {
+				got = append(got, val)
+				if len(got) == k {
+					break
+				}
+			}
+			if !slices.Equal(got, want[:k]) {
+				t.Errorf("%s: first %d = %v, want %v", name, k, got, want[:k])
+			}
+		}
+	}
+}
+
+func TestSortedIter(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, in := range [][]int{{1}, {2, 1}, {3, 1, 3, 2, 1, 3}, RandomInts(1000, rng), FewUniqueInts(1000, rng)} {
+		want := slices.Clone(in)
+		slices.Sort(want)
+
+		vec := slices.Clone(in)
+		got := slices.Collect(SortedIter(vec))
+		if !slices.Equal(got, want) {
+			t.Fatalf("SortedIter(%v) yielded %v", in, got)
+		}
+		// everything got popped to the back, largest first
+		if !slices.Equal(vec, Reversed(want)) {
+			t.Errorf("SortedIter left vec as %v, want it in descending order", vec)
+		}
+	}
+}
+
+// Breaking after k elements only pops k times, the rest of vec is still the
+// heap SortedIter built and nowhere near sorted
+func TestSortedIterIsLazy(t *testing.T) {
+	const n, k = 1_000_000, 3
+	in := RandomInts(n, rand.New(rand.NewSource(1)))
+	vec := slices.Clone(in)
+
+	var got []int
+	for val := range SortedIter(vec) {
+		got = append(got, val)
+		if len(got) == k {
+			break
+		}
+	}
+
+	want := slices.Clone(in)
+	slices.Sort(want)
+	if !slices.Equal(got, want[:k]) {
+		t.Fatalf("first %d = %v, want %v", k, got, want[:k])
+	}
+
+	// the k popped elements sit at the back, the smallest last
+	if !slices.Equal(vec[n-k:], Reversed(want[:k])) {
+		t.Errorf("popped elements are %v, want %v", vec[n-k:], Reversed(want[:k]))
+	}
+
+	rest := vec[:n-k]
+	if !isHeap(rest, len(rest), func(parent, child int) bool { return parent <= child }) {
+		t.Errorf("the rest of vec isn't a min-heap")
+	}
+	if slices.IsSorted(rest) || slices.IsSorted(Reversed(rest)) {
+		t.Errorf("the rest of vec got sorted, SortedIter did more than %d pops", k)
+	}
+}
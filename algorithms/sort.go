@@ -0,0 +1,248 @@
+package algorithms
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Thresholds used by Sort to pick an algorithm
+const (
+	// Slices this small always go to InsertionSort
+	SmallSortThreshold = 16
+
+	// How many evenly spaced elements Sort looks at. Counting inversions in
+	// the sample is O(SampleSize^2) no matter how big the slice is
+	SampleSize = 32
+
+	// If at most this fraction of the sampled pairs are inversions the slice
+	// is treated as nearly sorted
+	NearlySortedInversionRatio = 0.05
+
+	// If at least this fraction of the sorted sample equals its neighbour the
+	// slice is treated as duplicate heavy
+	HighDuplicateRatio = 0.5
+
+	// Insertion sort on a "nearly sorted" slice gives up after
+	// NearlySortedBudgetPerElement*len(vec) shifts, in case the sample lied
+	NearlySortedBudgetPerElement = 4
+)
+
+// Looks at the input and picks an algorithm for it:
+//   - tiny slices go to InsertionSort
+//   - nearly sorted slices go to InsertionSort (with a budget, see above)
+//   - duplicate heavy slices go to ThreeWayQuickSort
+//   - everything else goes to IntroSort
+func Sort[T Ordered](vec []T) {
+	if len(vec) <= SmallSortThreshold {
+		InsertionSort(vec)
+		return
+	}
+
+	nearlySorted, duplicateHeavy := classifyInput(vec)
+
+	if nearlySorted {
+		if _, ok := insertionSortBudget(vec, NearlySortedBudgetPerElement*len(vec)); ok {
+			return
+		}
+	}
+
+	if duplicateHeavy {
+		ThreeWayQuickSort(vec)
+		return
+	}
+
+	IntroSort(vec)
+}
+
+// The two questions Sort asks about a slice bigger than SmallSortThreshold,
+// answered from sampleStats and the thresholds above
+func classifyInput[T Ordered](vec []T) (nearlySorted, duplicateHeavy bool) {
+	inversionRatio, duplicateRatio := sampleStats(vec)
+	return inversionRatio <= NearlySortedInversionRatio, duplicateRatio >= HighDuplicateRatio
+}
+
+// Takes SampleSize evenly spaced elements and returns the fraction of pairs
+// in the sample that are inversions and the fraction of elements in the
+// sample that are duplicates of another sampled element
+func sampleStats[T Ordered](vec []T) (inversionRatio float64, duplicateRatio float64) {
+	size := min(SampleSize, len(vec))
+	step := len(vec) / size
+
+	sample := make([]T, size)
+	for i := range sample {
+		sample[i] = vec[i*step]
+	}
+
+	inversions := 0
+	for i := 0; i < size; i++ {
+		for j := i + 1; j < size; j++ {
+			if sample[i] > sample[j] {
+				inversions++
+			}
+		}
+	}
+
+	InsertionSort(sample)
+	duplicates := 0
+	for i := 1; i < size; i++ {
+		if sample[i] == sample[i-1] {
+			duplicates++
+		}
+	}
+
+	pairs := size * (size - 1) / 2
+	return float64(inversions) / float64(pairs), float64(duplicates) / float64(size)
+}
+
+// InsertionSort that stops once it has done more than budget shifts. Every
+// shift fixes exactly one inversion, so this is O(n + budget). Returns how
+// many shifts it did and false if it gave up, vec is still a permutation of
+// the input in that case so it can be handed to any other sort.
+func insertionSortBudget[T Ordered](vec []T, budget int) (shifts int, ok bool) {
+	for i := 1; i < len(vec); i++ {
+		for j := i; j > 0 && vec[j] < vec[j-1]; j-- {
+			if shifts == budget {
+				return shifts, false
+			}
+			vec[j], vec[j-1] = vec[j-1], vec[j]
+			shifts++
+		}
+	}
+	return shifts, true
+}
+
+// Tries InsertionSort first and only falls back to IntroSort if the slice has
+// more than NearlySortedBudgetPerElement*len(vec) inversions. On nearly sorted
+// input that is O(n), on random input the insertion sort gives up after O(n)
+// work so the extra cost is small
+func SortAdaptive[T Ordered](vec []T) {
+	SortAdaptiveWithThreshold(vec, NearlySortedBudgetPerElement*len(vec))
+}
+
+// Same as SortAdaptive but with a custom inversion threshold. The attempt
+// costs at most O(n + maxInversions)
+//
+// A descending run at the start is reversed first, so reverse sorted input
+// turns into sorted input and is done in O(n) too.
+func SortAdaptiveWithThreshold[T Ordered](vec []T, maxInversions int) {
+	sortAdaptive(vec, maxInversions)
+}
+
+// Sorts vec and returns how many swaps it did before IntroSort, and whether
+// it had to fall back to IntroSort at all
+func sortAdaptive[T Ordered](vec []T, maxInversions int) (swaps int, fellBack bool) {
+	run := 1
+	for run < len(vec) && vec[run] <= vec[run-1] {
+		run++
+	}
+
+	if run > 1 {
+		Reverse(vec[:run])
+		swaps = run / 2
+		if run == len(vec) {
+			return swaps, false
+		}
+	}
+
+	shifts, ok := insertionSortBudget(vec, max(maxInversions, 0))
+	swaps += shifts
+	if ok {
+		return swaps, false
+	}
+
+	IntroSort(vec)
+	return swaps, true
+}
+
+// Sorts only vec[lo:hi], everything outside of it is left exactly as is.
+// Panics unless 0 <= lo <= hi <= len(vec), same as slicing would
+func SortRange[T Ordered](vec []T, lo, hi int) {
+	if lo < 0 || lo > hi || hi > len(vec) {
+		panic(fmt.Sprintf("algorithms: SortRange bounds [%d:%d] out of range for length %d", lo, hi, len(vec)))
+	}
+
+	IntroSort(vec[lo:hi])
+}
+
+// Sorts vec and reports whether it was already sorted, e.g. to tell if
+// anything changed. The check is a single scan that stops at the first out
+// of order pair, so an already sorted slice costs O(n) and is never touched
+func SortAndReport[T Ordered](vec []T) (wasSorted bool) {
+	for i := 1; i < len(vec); i++ {
+		if vec[i] < vec[i-1] {
+			Sort(vec)
+			return false
+		}
+	}
+	return true
+}
+
+// Sorts vec with at most maxComparisons comparisons and reports whether it
+// got all the way. It's a binary insertion sort: vec[:i] is kept sorted and
+// every step binary searches where vec[i] goes and shifts it there. That
+// takes O(n log n) comparisons in total (the shifts are free, they don't
+// compare anything), and if the budget runs out the search that was going on
+// is dropped, so vec is a sorted prefix followed by the untouched rest of the
+// input. Never more out of order than the input, any other sort can finish
+// the job later
+func SortBudget[T Ordered](vec []T, maxComparisons int) (complete bool) {
+	budget := maxComparisons
+
+	for i := 1; i < len(vec); i++ {
+		lo, hi := 0, i
+		for lo < hi {
+			if budget <= 0 {
+				return false
+			}
+			budget--
+
+			mid := lo + (hi-lo)/2
+			// <= so equal elements stay in order
+			if vec[mid] <= vec[i] {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+
+		val := vec[i]
+		copy(vec[lo+1:i+1], vec[lo:i])
+		vec[lo] = val
+	}
+
+	return true
+}
+
+// For a sorted slice that had a few elements appended: vec[:start] has to be
+// sorted already, vec[start:] can be in any order. The tail is sorted on its
+// own and then merged in from the back, binary searching where each tail
+// element goes and moving the bigger prefix elements out of the way in one
+// copy. With m new elements that is O(m log m + m log n) comparisons, O(n)
+// moves and O(m) extra memory, instead of sorting all of vec again. Stable
+// for the prefix, tail elements go after prefix elements equal to them.
+// Panics unless 0 <= start <= len(vec)
+func InsertSorted[T Ordered](vec []T, start int) {
+	if start < 0 || start > len(vec) {
+		panic(fmt.Sprintf("algorithms: InsertSorted start %d out of range for length %d", start, len(vec)))
+	}
+
+	insertSorted(vec, start)
+}
+
+// Does the InsertSorted merge and returns how many elements it wrote into
+// vec. Every prefix element moves at most once, so that's at most len(vec)
+func insertSorted[T Ordered](vec []T, start int) (moves int) {
+	tail := slices.Clone(vec[start:])
+	IntroSort(tail)
+
+	// i is the end of the prefix that hasn't moved yet
+	i := start
+	for j := len(tail) - 1; j >= 0; j-- {
+		pos := UpperBound(vec[:i], tail[j])
+		moves += copy(vec[pos+j+1:i+j+1], vec[pos:i])
+		vec[pos+j] = tail[j]
+		moves++
+		i = pos
+	}
+	return moves
+}
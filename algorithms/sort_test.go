@@ -0,0 +1,363 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSortDispatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 10_000
+
+	nearlySorted := SortedInts(n)
+	for i := 0; i < 5; i++ {
+		a, b := rng.Intn(n), rng.Intn(n)
+		nearlySorted[a], nearlySorted[b] = nearlySorted[b], nearlySorted[a]
+	}
+	sortedFewUnique := FewUniqueInts(n, rng)
+	slices.Sort(sortedFewUnique)
+	twoValues := make([]int, n)
+	for i := range twoValues {
+		twoValues[i] = rng.Intn(2)
+	}
+
+	tests := []struct {
+		name           string
+		vec            []int
+		nearlySorted   bool
+		duplicateHeavy bool
+	}{
+		{"random", RandomInts(n, rng), false, false},
+		{"sorted", SortedInts(n), true, false},
+		{"nearly sorted", nearlySorted, true, false},
+		{"reverse", ReverseSortedInts(n), false, false},
+		{"few unique", FewUniqueInts(n, rng), false, true},
+		{"two values", twoValues, false, true},
+		{"sorted few unique", sortedFewUnique, true, true},
+		{"all equal", make([]int, n), true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nearly, dups := classifyInput(tt.vec)
+			if nearly != tt.nearlySorted || dups != tt.duplicateHeavy {
+				t.Errorf("classifyInput = nearly sorted %v, duplicate heavy %v, want %v, %v",
+					nearly, dups, tt.nearlySorted, tt.duplicateHeavy)
+			}
+
+			vec := slices.Clone(tt.vec)
+			Sort(vec)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(tt.vec))) {
+				t.Errorf("Sort didn't sort %s input", tt.name)
+			}
+		})
+	}
+}
+
+// The sample only sees every n/SampleSize-th element, it can call a slice
+// nearly sorted that isn't. The insertion sort budget runs out and Sort
+// still has to finish the job
+func TestSortSampleLies(t *testing.T) {
+	n := SampleSize * 100
+	vec := SortedInts(n)
+	// reverse everything between the sampled elements
+	for i := 0; i+100 <= n; i += 100 {
+		slices.Reverse(vec[i+1 : i+100])
+	}
+
+	if nearly, _ := classifyInput(vec); !nearly {
+		t.Fatalf("the sample should look sorted")
+	}
+	if _, ok := insertionSortBudget(slices.Clone(vec), NearlySortedBudgetPerElement*n); ok {
+		t.Fatalf("the budget should run out")
+	}
+
+	Sort(vec)
+	if !slices.IsSorted(vec) {
+		t.Errorf("Sort didn't finish after the insertion sort gave up")
+	}
+}
+
+func TestSortSmall(t *testing.T) {
+	for n := 0; n <= SmallSortThreshold+1; n++ {
+		vec := ReverseSortedInts(n)
+		Sort(vec)
+		if !slices.Equal(vec, SortedInts(n)) {
+			t.Errorf("n=%d: Sort = %v", n, vec)
+		}
+	}
+}
+
+func TestSortRange(t *testing.T) {
+	in := RandomInts(100, rand.New(rand.NewSource(1)))
+
+	tests := []struct {
+		name   string
+		lo, hi int
+	}{
+		{"middle", 20, 60},
+		{"prefix", 0, 30},
+		{"suffix", 70, 100},
+		{"everything", 0, 100},
+		{"empty", 50, 50},
+		{"empty at the end", 100, 100},
+		{"one element", 10, 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(in)
+			SortRange(vec, tt.lo, tt.hi)
+
+			if !slices.Equal(vec[:tt.lo], in[:tt.lo]) || !slices.Equal(vec[tt.hi:], in[tt.hi:]) {
+				t.Errorf("SortRange(%d, %d) touched elements outside the range", tt.lo, tt.hi)
+			}
+			want := slices.Sorted(slices.Values(in[tt.lo:tt.hi]))
+			if !slices.Equal(vec[tt.lo:tt.hi], want) {
+				t.Errorf("SortRange(%d, %d) = %v, want %v", tt.lo, tt.hi, vec[tt.lo:tt.hi], want)
+			}
+		})
+	}
+}
+
+func TestSortRangePanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi int
+	}{
+		{"negative lo", -1, 5},
+		{"lo after hi", 6, 5},
+		{"hi past the end", 0, 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := SortedInts(10)
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SortRange(%d, %d) didn't panic", tt.lo, tt.hi)
+				}
+				if !slices.Equal(vec, SortedInts(10)) {
+					t.Errorf("SortRange changed vec before panicking")
+				}
+			}()
+			SortRange(vec, tt.lo, tt.hi)
+		})
+	}
+}
+
+func TestSortAndReport(t *testing.T) {
+	singleInversion := SortedInts(100)
+	singleInversion[40], singleInversion[41] = singleInversion[41], singleInversion[40]
+
+	tests := []struct {
+		name string
+		vec  []int
+		want bool
+	}{
+		{"empty", nil, true},
+		{"single", []int{1}, true},
+		{"sorted", SortedInts(100), true},
+		{"sorted with duplicates", []int{1, 1, 2, 2, 2, 3}, true},
+		{"single inversion", singleInversion, false},
+		{"last pair swapped", []int{1, 2, 3, 5, 4}, false},
+		{"reverse", ReverseSortedInts(100), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			if got := SortAndReport(vec); got != tt.want {
+				t.Errorf("SortAndReport = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(vec, slices.Sorted(slices.Values(tt.vec))) {
+				t.Errorf("SortAndReport didn't sort: %v", vec)
+			}
+		})
+	}
+}
+
+func TestSortAdaptiveReversed(t *testing.T) {
+	n := 1000
+	withDuplicates := make([]int, n)
+	for i := range withDuplicates {
+		withDuplicates[i] = (n - i) / 3
+	}
+	// descending prefix, then the values above it in order
+	prefix := append(ReverseSortedInts(n/2), SortedInts(n)[n/2:]...)
+	// descending prefix, then a tail that doesn't fit behind it
+	mixed := append(ReverseSortedInts(n/2), RandomInts(n/2, rand.New(rand.NewSource(1)))...)
+
+	tests := []struct {
+		name string
+		vec  []int
+	}{
+		{"reversed", ReverseSortedInts(n)},
+		{"reversed with duplicates", withDuplicates},
+		{"all equal", make([]int, n)},
+		{"reversed prefix", prefix},
+		{"reversed prefix random tail", mixed},
+		{"two", []int{2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			SortAdaptive(vec)
+			if !slices.Equal(vec, slices.Sorted(slices.Values(tt.vec))) {
+				t.Errorf("SortAdaptive didn't sort %s input", tt.name)
+			}
+		})
+	}
+}
+
+// Reversing the descending run is all it takes, n/2 swaps, and the insertion
+// sort gets a sorted slice so it doesn't need any of its budget. Even a
+// budget of 0 doesn't send it to IntroSort
+func TestSortAdaptiveReversedIsLinear(t *testing.T) {
+	for _, n := range []int{2, 1001, 1_000_000} {
+		for _, budget := range []int{0, NearlySortedBudgetPerElement * n} {
+			vec := ReverseSortedInts(n)
+			swaps, fellBack := sortAdaptive(vec, budget)
+			if !slices.Equal(vec, SortedInts(n)) {
+				t.Fatalf("n=%d, budget %d: not sorted", n, budget)
+			}
+			if fellBack || swaps != n/2 {
+				t.Errorf("n=%d, budget %d: %d swaps, fell back to IntroSort: %v, want %d swaps without it", n, budget, swaps, fellBack, n/2)
+			}
+		}
+	}
+
+	// after the reversed run the 500 has 501 inversions to shift past, a
+	// budget of 500 isn't enough and 501 is
+	for _, budget := range []int{500, 501} {
+		vec := append(ReverseSortedInts(1000), 1000, 1001, 500, 1002)
+		swaps, fellBack := sortAdaptive(vec, budget)
+		if !slices.IsSorted(vec) {
+			t.Fatalf("budget %d: not sorted", budget)
+		}
+		if want := budget < 501; fellBack != want || swaps != 500+budget {
+			t.Errorf("budget %d: %d swaps, fell back to IntroSort: %v, want %d swaps and %v", budget, swaps, fellBack, 500+budget, want)
+		}
+	}
+}
+
+func TestSortBudget(t *testing.T) {
+	in := RandomInts(1000, rand.New(rand.NewSource(1)))
+	inversions := CountInversions(slices.Clone(in))
+
+	for _, budget := range []int{0, 1, 10, 100, 1000, 5000} {
+		vec := slices.Clone(in)
+		if SortBudget(vec, budget) {
+			t.Fatalf("SortBudget finished 1000 random elements with a budget of %d", budget)
+		}
+
+		if !slices.Equal(slices.Sorted(slices.Values(vec)), slices.Sorted(slices.Values(in))) {
+			t.Fatalf("budget %d: output isn't a permutation of the input", budget)
+		}
+
+		// a sorted prefix followed by the rest exactly as it was
+		prefix := 1
+		for prefix < len(vec) && vec[prefix] >= vec[prefix-1] {
+			prefix++
+		}
+		if !slices.Equal(vec[prefix:], in[prefix:]) {
+			t.Errorf("budget %d: touched elements past the sorted prefix of %d", budget, prefix)
+		}
+		if got := CountInversions(slices.Clone(vec)); got > inversions {
+			t.Errorf("budget %d: %d inversions, the input had %d", budget, got, inversions)
+		}
+	}
+
+	// binary insertion sort needs less than n*log2(n) comparisons
+	vec := slices.Clone(in)
+	if !SortBudget(vec, 1000*10) {
+		t.Fatalf("SortBudget didn't finish with a budget of n log n")
+	}
+	if !slices.IsSorted(vec) {
+		t.Errorf("SortBudget reported complete but didn't sort")
+	}
+}
+
+// Equal elements keep their input order, -0 and +0 tell them apart
+func TestSortBudgetStable(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	vec := []float64{0, 3, negZero, 1, 0, negZero, -2}
+	if !SortBudget(vec, 100) {
+		t.Fatalf("SortBudget didn't finish 7 elements")
+	}
+
+	signs := []bool{false, true, false, true}
+	var got []bool
+	for _, val := range vec {
+		if val == 0 {
+			got = append(got, math.Signbit(val))
+		}
+	}
+	if !slices.IsSorted(vec) || !slices.Equal(got, signs) {
+		t.Errorf("SortBudget = %v, zeros out of their input order", vec)
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		n := rng.Intn(60)
+		in := FewUniqueInts(n, rng)
+		if i%2 == 0 {
+			in = RandomInts(n, rng)
+		}
+		start := rng.Intn(n + 1)
+		slices.Sort(in[:start])
+
+		vec := slices.Clone(in)
+		InsertSorted(vec, start)
+		want := slices.Sorted(slices.Values(in))
+		if !slices.Equal(vec, want) {
+			t.Fatalf("InsertSorted(%v, %d) = %v, want %v", in, start, vec, want)
+		}
+	}
+}
+
+func TestInsertSortedPanics(t *testing.T) {
+	for _, start := range []int{-1, 4} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("InsertSorted with start %d didn't panic", start)
+				}
+			}()
+			InsertSorted([]int{1, 2, 3}, start)
+		}()
+	}
+}
+
+// A few elements appended to a sorted million need one pass of moves at most,
+// and only over the part of the prefix that's bigger than the smallest of them
+func TestInsertSortedSmallTailIsLinear(t *testing.T) {
+	const n = 1_000_000
+	tests := []struct {
+		tail  []int
+		moves int
+	}{
+		// -1 goes in front, so everything moves
+		{[]int{3, n + 7, -1, n / 2, 12345}, n + 5},
+		// nothing in the prefix is bigger
+		{[]int{n + 2, n, n + 1}, 3},
+		// everything from n/2 up moves, after the n/2 that's already there
+		{[]int{n - 1, n / 2}, n/2 - 1 + 2},
+	}
+
+	for _, tt := range tests {
+		vec := append(SortedInts(n), tt.tail...)
+		want := slices.Sorted(slices.Values(vec))
+		if moves := insertSorted(vec, n); moves != tt.moves {
+			t.Errorf("tail %v: %d moves, want %d", tt.tail, moves, tt.moves)
+		}
+		if !slices.Equal(vec, want) {
+			t.Errorf("tail %v: not sorted", tt.tail)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package algorithms
+
+// Any in place sort over an Ordered slice, so algorithms can be passed
+// around and picked at runtime
+type Sorter[T Ordered] func(vec []T)
+
+// Every general purpose comparison sort in the package, by a short name.
+// It's called Sorters and not Algorithms because that one is already the
+// []int list RunBenchmark uses. A new map is returned every time, changing
+// it doesn't affect other callers
+func Sorters[T Ordered]() map[string]Sorter[T] {
+	return map[string]Sorter[T]{
+		"simple":     SimpleSort[T],
+		"selection":  SelectionSort[T],
+		"bubble":     BubbleSort[T],
+		"oddeven":    OddEvenSort[T],
+		"insertion":  InsertionSort[T],
+		"merge":      MergeSort[T],
+		"mergebu":    MergeSortBottomUp[T],
+		"symmerge":   SymMergeSort[T],
+		"blockmerge": BlockMergeSort[T],
+		"quick":      QuickSort[T],
+		"threeway":   ThreeWayQuickSort[T],
+		"dualpivot":  DualPivotQuickSort[T],
+		"intro":      IntroSort[T],
+		"pdq":        PDQSort[T],
+		"heap":       HeapSort[T],
+		"bitonic":    BitonicSort[T],
+		"sample":     SampleSort[T],
+		"adaptive":   SortAdaptive[T],
+		"sort":       Sort[T],
+	}
+}
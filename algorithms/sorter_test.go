@@ -0,0 +1,47 @@
+package algorithms
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// The registry includes the quadratic sorts, so the shared slice stays small
+func TestSorters(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ints := append(RandomInts(1500, rng), FewUniqueInts(500, rng)...)
+	strs := make([]string, 500)
+	for i := range strs {
+		strs[i] = fmt.Sprint(rng.Intn(100))
+	}
+
+	testSorters(t, ints)
+	testSorters(t, strs)
+}
+
+func testSorters[T Ordered](t *testing.T, in []T) {
+	t.Helper()
+	want := slices.Sorted(slices.Values(in))
+	for name, sort := range Sorters[T]() {
+		vec := slices.Clone(in)
+		sort(vec)
+		if !slices.Equal(vec, want) {
+			t.Errorf("Sorters[%T][%q] didn't sort", in, name)
+		}
+	}
+}
+
+func TestSortersNewMap(t *testing.T) {
+	sorters := Sorters[int]()
+	for _, name := range []string{"quick", "merge", "heap", "sort"} {
+		if sorters[name] == nil {
+			t.Errorf("Sorters has no %q", name)
+		}
+	}
+
+	delete(sorters, "quick")
+	if Sorters[int]()["quick"] == nil {
+		t.Errorf("deleting from one Sorters map changed the next one")
+	}
+}
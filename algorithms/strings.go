@@ -0,0 +1,193 @@
+package algorithms
+
+import "strings"
+
+// Shorter strings first, strings of the same length in lexicographic order.
+// ["bb" "a" "aaa" "cc"] becomes ["a" "bb" "cc" "aaa"]. Stable
+func StringSortByLength(vec []string) {
+	MergeSortCmp(vec, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+		return strings.Compare(a, b)
+	})
+}
+
+// Sorts so that numbers inside the strings compare by value, "file2" comes
+// before "file10". Digits are ASCII 0-9, everything else compares byte by
+// byte. Stable
+func NaturalSort(vec []string) {
+	MergeSortCmp(vec, naturalCompare)
+}
+
+func naturalCompare(a, b string) int {
+	// if everything else is equal, the string with fewer leading zeros
+	// goes first so "a1" < "a01" and the order is always the same
+	zerosTieBreak := 0
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if !isDigit(a[i]) || !isDigit(b[j]) {
+			if a[i] != b[j] {
+				return int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+			continue
+		}
+
+		// both sides are at a number, skip the leading zeros
+		zerosA, zerosB := i, j
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		zerosA, zerosB = i-zerosA, j-zerosB
+
+		startA, startB := i, j
+		for i < len(a) && isDigit(a[i]) {
+			i++
+		}
+		for j < len(b) && isDigit(b[j]) {
+			j++
+		}
+
+		// more digits means a bigger number, otherwise compare digit by digit
+		numA, numB := a[startA:i], b[startB:j]
+		if len(numA) != len(numB) {
+			return len(numA) - len(numB)
+		}
+		if c := strings.Compare(numA, numB); c != 0 {
+			return c
+		}
+
+		if zerosTieBreak == 0 {
+			zerosTieBreak = zerosA - zerosB
+		}
+	}
+
+	// one of them ran out, the shorter one goes first
+	if rest := (len(a) - i) - (len(b) - j); rest != 0 {
+		return rest
+	}
+	return zerosTieBreak
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// Sorts strings with any ordering the caller wants, e.g. a locale aware
+// collator's CompareString. This is the slow but flexible path, for plain
+// ASCII byte order StringRadixSort is faster. Stable
+func StringSortWith(vec []string, compare func(a, b string) int) {
+	MergeSortCmp(vec, compare)
+}
+
+// Sorts runes by code point, in place
+func RuneSort(r []rune) {
+	IntroSort(r)
+}
+
+// s with its characters sorted by code point, "banana" becomes "aaabnn".
+// Works on runes, not bytes, so multi-byte characters stay in one piece.
+// Invalid UTF-8 bytes each turn into utf8.RuneError
+func SortRunes(s string) string {
+	r := []rune(s)
+	RuneSort(r)
+	return string(r)
+}
+
+// Buckets of at most this many strings are finished with InsertionSort by
+// AmericanFlagSort
+const flagSortCutoff = 16
+
+// How deep americanFlagSort recurses before it hands a bucket to PDQSort.
+// Every level keeps three count arrays on the stack, about 6 KB
+const flagSortMaxDepth = 64
+
+// In place MSD radix sort on the bytes of the strings, same order as < on
+// strings. Every pass counts how many strings have each byte at the current
+// position, then swaps every string straight into its bucket by following
+// cycles (no output slice like StringRadixSort needs) and recurses into each
+// bucket on the next position. Strings that ended come first in their bucket
+// and are done. When all of the strings land in one bucket it just moves on
+// to the next position without recursing, so a long shared prefix costs no
+// stack, and past flagSortMaxDepth levels of recursion the rest is sorted
+// with PDQSort. Not stable, which doesn't matter for strings that are equal
+func AmericanFlagSort(vec []string) {
+	americanFlagSort(vec, 0, 0)
+}
+
+// depth is the byte position, level how deep the recursion is
+func americanFlagSort(vec []string, depth int, level int) {
+	for {
+		if len(vec) <= flagSortCutoff {
+			InsertionSort(vec)
+			return
+		}
+		if level >= flagSortMaxDepth {
+			PDQSort(vec)
+			return
+		}
+
+		// bucket 0 is for strings that are shorter than depth+1
+		var counts [257]int
+		for _, s := range vec {
+			counts[flagBucket(s, depth)]++
+		}
+
+		// everything in one bucket, nothing to move
+		if b := flagBucket(vec[0], depth); counts[b] == len(vec) {
+			if b == 0 {
+				return
+			}
+			depth++
+			continue
+		}
+
+		// next[b] is the first slot in bucket b that doesn't hold one of its
+		// own strings yet
+		var starts, next [257]int
+		sum := 0
+		for b, c := range counts {
+			starts[b], next[b] = sum, sum
+			sum += c
+		}
+
+		for b := range counts {
+			end := starts[b] + counts[b]
+			for next[b] < end {
+				s := vec[next[b]]
+				c := flagBucket(s, depth)
+
+				// s goes to the next free slot of its bucket and whatever
+				// was there gets placed next, until the cycle gets back to
+				// bucket b
+				for c != b {
+					vec[next[c]], s = s, vec[next[c]]
+					next[c]++
+					c = flagBucket(s, depth)
+				}
+				vec[next[b]] = s
+				next[b]++
+			}
+		}
+
+		for b := 1; b < len(counts); b++ {
+			if counts[b] > 1 {
+				americanFlagSort(vec[starts[b]:starts[b]+counts[b]], depth+1, level+1)
+			}
+		}
+		return
+	}
+}
+
+func flagBucket(s string, depth int) int {
+	if depth < len(s) {
+		return int(s[depth]) + 1
+	}
+	return 0
+}
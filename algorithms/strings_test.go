@@ -0,0 +1,328 @@
+package algorithms
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestStringRadixSort(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []string
+		want []string
+	}{
+		{"prefixes", []string{"apple", "app", "applet", "app"}, []string{"app", "app", "apple", "applet"}},
+		{"empty strings", []string{"b", "", "a", "", "ab"}, []string{"", "", "a", "ab", "b"}},
+		{"only empty", []string{"", "", ""}, []string{"", "", ""}},
+		{"zero bytes", []string{"a\x00", "a", "\x00", "", "a\x00\x00"}, []string{"", "\x00", "a", "a\x00", "a\x00\x00"}},
+		{"0xff bytes", []string{"\xff", "a\xff", "\xff\x00", "a", "\xfe"}, []string{"a", "a\xff", "\xfe", "\xff", "\xff\x00"}},
+		{"utf8", []string{"héllo", "hello", "hzllo", "h"}, []string{"h", "hello", "hzllo", "héllo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			StringRadixSort(vec)
+			if !slices.Equal(vec, tt.want) {
+				t.Errorf("StringRadixSort(%q) = %q, want %q", tt.vec, vec, tt.want)
+			}
+		})
+	}
+}
+
+// Equal strings can only be told apart by where their bytes live, so the two
+// "app"s are cut out of different places of one buffer
+func TestStringRadixSortStable(t *testing.T) {
+	buf := "apple app applet app"
+	first, second := buf[6:9], buf[17:20]
+
+	vec := []string{"apple", first, "applet", second}
+	StringRadixSort(vec)
+
+	if !slices.Equal(vec, []string{"app", "app", "apple", "applet"}) {
+		t.Fatalf("StringRadixSort = %q", vec)
+	}
+	if unsafe.StringData(vec[0]) != unsafe.StringData(first) || unsafe.StringData(vec[1]) != unsafe.StringData(second) {
+		t.Errorf("the two \"app\"s swapped places")
+	}
+}
+
+func TestStringSortByLength(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []string
+		want []string
+	}{
+		{"example", []string{"bb", "a", "aaa", "cc"}, []string{"a", "bb", "cc", "aaa"}},
+		{"duplicates", []string{"xy", "b", "xy", "a", "b", "ab"}, []string{"a", "b", "b", "ab", "xy", "xy"}},
+		{"empty strings", []string{"a", "", "", "ba"}, []string{"", "", "a", "ba"}},
+		// bytes, not runes: é is two bytes long
+		{"utf8", []string{"é", "ab", "z"}, []string{"z", "ab", "é"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			StringSortByLength(vec)
+			if !slices.Equal(vec, tt.want) {
+				t.Errorf("StringSortByLength(%q) = %q, want %q", tt.vec, vec, tt.want)
+			}
+		})
+	}
+}
+
+// Duplicates of mixed lengths cut out of different places of one buffer, so
+// equal strings can be told apart like in TestStringRadixSortStable
+func TestStringSortByLengthStable(t *testing.T) {
+	buf := "bb a bb ccc a bb"
+	vec := []string{buf[0:2], buf[3:4], buf[5:7], buf[8:11], buf[12:13], buf[14:16]}
+	in := slices.Clone(vec)
+
+	StringSortByLength(vec)
+
+	// positions in the input of every output string
+	var order []int
+	for _, s := range vec {
+		for i, orig := range in {
+			if unsafe.StringData(s) == unsafe.StringData(orig) {
+				order = append(order, i)
+			}
+		}
+	}
+	if want := []int{1, 4, 0, 2, 5, 3}; !slices.Equal(order, want) {
+		t.Errorf("StringSortByLength put the input at %v, want %v", order, want)
+	}
+}
+
+func TestNaturalSort(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []string
+		want []string
+	}{
+		{"example", []string{"a10", "a2", "a1"}, []string{"a1", "a2", "a10"}},
+		{"files", []string{"file10.txt", "file2.txt", "file1.txt", "file1.md"}, []string{"file1.md", "file1.txt", "file2.txt", "file10.txt"}},
+		{"versions", []string{"v1.10.0", "v1.2.10", "v1.2.9", "v1.2", "v0.9"}, []string{"v0.9", "v1.2", "v1.2.9", "v1.2.10", "v1.10.0"}},
+		{"leading zeros", []string{"a01", "a1", "a001", "a2", "a02"}, []string{"a1", "a01", "a001", "a2", "a02"}},
+		{"only zeros", []string{"x00", "x0", "x000"}, []string{"x0", "x00", "x000"}},
+		{"numbers only", []string{"100", "20", "3", "020"}, []string{"3", "20", "020", "100"}},
+		{"number vs letter", []string{"ab", "a1", "a", "1"}, []string{"1", "a", "a1", "ab"}},
+		{"huge numbers", []string{"n123456789012345678901", "n99999999999999999999"}, []string{"n99999999999999999999", "n123456789012345678901"}},
+		{"tails", []string{"img12b", "img12a", "img12", "img2z"}, []string{"img2z", "img12", "img12a", "img12b"}},
+		{"empty", []string{"b", "", "a"}, []string{"", "a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			NaturalSort(vec)
+			if !slices.Equal(vec, tt.want) {
+				t.Errorf("NaturalSort(%q) = %q, want %q", tt.vec, vec, tt.want)
+			}
+		})
+	}
+}
+
+// Deterministic means a total order: antisymmetric and equal only for equal
+// strings, so the input order never shows through
+func TestNaturalCompareIsTotal(t *testing.T) {
+	words := []string{"", "a", "a0", "a00", "a1", "a01", "a10", "a1b", "a01b", "b", "0", "00", "1", "x9y9", "x09y9", "x9y09"}
+	for _, a := range words {
+		for _, b := range words {
+			c, d := naturalCompare(a, b), naturalCompare(b, a)
+			if (c < 0) != (d > 0) || (c == 0) != (a == b) {
+				t.Errorf("naturalCompare(%q, %q) = %d, reversed %d", a, b, c, d)
+			}
+		}
+	}
+}
+
+func TestStringSortWith(t *testing.T) {
+	tests := []struct {
+		name    string
+		vec     []string
+		compare func(a, b string) int
+		want    []string
+	}{
+		{"reversed", []string{"b", "c", "a"}, func(a, b string) int { return strings.Compare(b, a) }, []string{"c", "b", "a"}},
+		{"case insensitive", []string{"b", "A", "a", "B"}, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) }, []string{"A", "a", "b", "B"}},
+		{"natural", []string{"a10", "a2"}, naturalCompare, []string{"a2", "a10"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			StringSortWith(vec, tt.compare)
+			if !slices.Equal(vec, tt.want) {
+				t.Errorf("StringSortWith(%q) = %q, want %q", tt.vec, vec, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortRunes(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"empty", "", ""},
+		{"banana", "banana", "aaabnn"},
+		{"multi-byte", "héllo wörld", " dhlllorwéö"},
+		{"cjk", "語日本", "日本語"},
+		{"emoji", "b😀a", "ab😀"},
+		// every invalid byte decodes to U+FFFD, which sorts after ASCII
+		{"invalid utf8", "b\xffa\xfe", "ab��"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SortRunes(tt.s); got != tt.want {
+				t.Errorf("SortRunes(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuneSort(t *testing.T) {
+	r := []rune("zäa日")
+	RuneSort(r)
+	if want := []rune("azä日"); !slices.Equal(r, want) {
+		t.Errorf("RuneSort = %q, want %q", string(r), string(want))
+	}
+}
+
+// Every string of up to 3 bytes over a few letters, including the zero and
+// 0xff bytes, so there are prefixes of all lengths and both ends of the byte
+// range. Slices bigger than flagSortCutoff so the cycle swaps actually run
+func flagSortUniverse() []string {
+	letters := []string{"\x00", "a", "b", "\xff"}
+	words := []string{""}
+	last := []string{""}
+	for depth := 0; depth < 3; depth++ {
+		var next []string
+		for _, w := range last {
+			for _, l := range letters {
+				next = append(next, w+l)
+			}
+		}
+		words = append(words, next...)
+		last = next
+	}
+	return words
+}
+
+func TestAmericanFlagSort(t *testing.T) {
+	universe := flagSortUniverse()
+	rng := rand.New(rand.NewSource(1))
+
+	check := func(in []string) {
+		t.Helper()
+		vec := slices.Clone(in)
+		AmericanFlagSort(vec)
+		if want := slices.Sorted(slices.Values(in)); !slices.Equal(vec, want) {
+			t.Fatalf("AmericanFlagSort(%q) = %q, want %q", in, vec, want)
+		}
+	}
+
+	// every string once, and twice, in many orders
+	for i := 0; i < 200; i++ {
+		vec := slices.Clone(universe)
+		if i%2 == 1 {
+			vec = append(vec, universe...)
+		}
+		Shuffle(vec, rng)
+		check(vec)
+	}
+
+	// every size around the cutoff, picked with repeats
+	for n := 0; n <= 4*flagSortCutoff; n++ {
+		for i := 0; i < 20; i++ {
+			vec := make([]string, n)
+			for j := range vec {
+				vec[j] = universe[rng.Intn(len(universe))]
+			}
+			check(vec)
+		}
+	}
+
+	check(slices.Repeat([]string{"same"}, 100))
+	check(slices.Repeat([]string{""}, 100))
+	check(Reversed(universe))
+}
+
+// A shared prefix used to cost a level of recursion per byte, and with a few
+// KB of count arrays per level 300 KB long strings blew the stack
+func TestAmericanFlagSortLongPrefixes(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	long := strings.Repeat("x", 300_000)
+
+	check := func(name string, in []string) {
+		t.Helper()
+		vec := slices.Clone(in)
+		Shuffle(vec, rng)
+		AmericanFlagSort(vec)
+		if !slices.Equal(vec, slices.Sorted(slices.Values(in))) {
+			t.Fatalf("%s: not sorted", name)
+		}
+	}
+
+	check("identical", slices.Repeat([]string{long}, 2*flagSortCutoff))
+
+	var suffixes []string
+	for i := 0; i < 4*flagSortCutoff; i++ {
+		suffixes = append(suffixes, long+string(rune('a'+i%26)), long[:i])
+	}
+	check("different ends", suffixes)
+
+	// every string one longer than the last, each level of recursion only
+	// splits off the one that ended, so it goes past flagSortMaxDepth
+	var staircase []string
+	for i := 0; i < 20*flagSortMaxDepth; i++ {
+		staircase = append(staircase, strings.Repeat("a", i), strings.Repeat("a", i)+"b")
+	}
+	check("staircase", staircase)
+}
+
+// Random lowercase words with a common prefix now and then, like a sorted
+// dictionary shuffled
+func randomWords(n int, rng *rand.Rand) []string {
+	prefixes := []string{"", "un", "re", "inter", "pre"}
+	vec := make([]string, n)
+	for i := range vec {
+		b := []byte(prefixes[rng.Intn(len(prefixes))])
+		for j := 3 + rng.Intn(8); j > 0; j-- {
+			b = append(b, byte('a'+rng.Intn(26)))
+		}
+		vec[i] = string(b)
+	}
+	return vec
+}
+
+func BenchmarkAmericanFlagSort(b *testing.B) {
+	sorts := []struct {
+		name string
+		sort func([]string)
+	}{
+		{"AmericanFlagSort", AmericanFlagSort},
+		{"StringRadixSort", StringRadixSort},
+		{"slices.Sort", slices.Sort[[]string]},
+	}
+
+	for _, n := range []int{1000, 100_000} {
+		input := randomWords(n, rand.New(rand.NewSource(1)))
+		for _, s := range sorts {
+			b.Run(fmt.Sprintf("%s/%d", s.name, n), func(b *testing.B) {
+				vec := make([]string, n)
+				for i := 0; i < b.N; i++ {
+					copy(vec, input)
+					s.sort(vec)
+				}
+			})
+		}
+	}
+}
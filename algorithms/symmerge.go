@@ -0,0 +1,135 @@
+package algorithms
+
+// Length of the runs SymMergeSort insertion sorts before merging
+const SymMergeRunSize = 20
+
+// Stable merge sort that doesn't allocate a buffer. Runs of SymMergeRunSize
+// are insertion sorted and then merged in place with SymMerge (Kim & Kutzner),
+// the same approach the standard library's stable sort takes.
+//
+// This is not a block merge sort like BlockMergeSort, which gets to
+// O(n log n) time with O(1) memory. SymMerge recurses, so it needs O(log n)
+// stack on top of O(1) extra memory, and while it does O(n log n)
+// comparisons, moving elements around in place takes O(n log^2 n) swaps.
+// So it's slower than MergeSort, which spends O(n) memory on its tmp buffer
+func SymMergeSort[T Ordered](vec []T) {
+	n := len(vec)
+
+	start, end := 0, SymMergeRunSize
+	for end <= n {
+		InsertionSort(vec[start:end])
+		start = end
+		end += SymMergeRunSize
+	}
+	InsertionSort(vec[start:n])
+
+	for blockSize := SymMergeRunSize; blockSize < n; blockSize *= 2 {
+		start, end = 0, 2*blockSize
+		for end <= n {
+			symMerge(vec, start, start+blockSize, end)
+			start = end
+			end += 2 * blockSize
+		}
+
+		if mid := start + blockSize; mid < n {
+			symMerge(vec, start, mid, n)
+		}
+	}
+}
+
+// Merges the sorted runs vec[a:m] and vec[m:b] in place. It finds a split of
+// both runs such that rotating the middle part puts everything in the right
+// half, then recurses on both halves
+func symMerge[T Ordered](vec []T, a, m, b int) {
+	// A single element on the left, binary search where it goes and shift it
+	// there. It goes after anything equal on the right, to keep it stable
+	if m-a == 1 {
+		i, j := m, b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if vec[h] < vec[a] {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		for k := a; k < i-1; k++ {
+			vec[k], vec[k+1] = vec[k+1], vec[k]
+		}
+		return
+	}
+
+	// Same for a single element on the right, it goes after anything equal
+	// on the left
+	if b-m == 1 {
+		i, j := a, m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(vec[m] < vec[h]) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		for k := m; k > i; k-- {
+			vec[k], vec[k-1] = vec[k-1], vec[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+
+	p := n - 1
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(vec[p-c] < vec[c]) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate(vec, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge(vec, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge(vec, mid, end, b)
+	}
+}
+
+// Swaps the blocks vec[a:m] and vec[m:b] in place, using only swaps of
+// equally sized pieces
+func rotate[T any](vec []T, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange(vec, m-i, m, j)
+			i -= j
+		} else {
+			swapRange(vec, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	swapRange(vec, m-i, m, i)
+}
+
+func swapRange[T any](vec []T, a, b, n int) {
+	for i := 0; i < n; i++ {
+		vec[a+i], vec[b+i] = vec[b+i], vec[a+i]
+	}
+}
@@ -0,0 +1,123 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Sign bits of the zeros in vec, in order. -0 == +0, so these show whether a
+// sort kept equal elements in their input order
+func zeroSigns(vec []float64) []bool {
+	var signs []bool
+	for _, val := range vec {
+		if val == 0 {
+			signs = append(signs, math.Signbit(val))
+		}
+	}
+	return signs
+}
+
+// Every merge SymMergeSort does is a symMerge, so that is what gets the
+// exhaustive treatment: every permutation up to length 8, split at every
+// point, both halves sorted and merged
+func TestSymMergeAllPermutations(t *testing.T) {
+	for n := 1; n <= 8; n++ {
+		for _, perm := range permutations(n) {
+			for m := 1; m < n; m++ {
+				vec := slices.Clone(perm)
+				InsertionSort(vec[:m])
+				InsertionSort(vec[m:])
+				symMerge(vec, 0, m, n)
+				if !slices.IsSorted(vec) {
+					t.Fatalf("symMerge(%v, m=%d) = %v", perm, m, vec)
+				}
+			}
+		}
+	}
+}
+
+// Every sequence of up to 8 values out of -1, -0, +0, 1, split at every point
+func TestSymMergeStable(t *testing.T) {
+	alphabet := []float64{-1, math.Copysign(0, -1), 0, 1}
+
+	for n := 1; n <= 8; n++ {
+		seq := make([]int, n)
+		for {
+			vec := make([]float64, n)
+			for i, c := range seq {
+				vec[i] = alphabet[c]
+			}
+
+			for m := 1; m < n; m++ {
+				merged := slices.Clone(vec)
+				InsertionSort(merged[:m])
+				InsertionSort(merged[m:])
+				want := zeroSigns(merged)
+				symMerge(merged, 0, m, n)
+				if !slices.IsSorted(merged) || !slices.Equal(zeroSigns(merged), want) {
+					t.Fatalf("symMerge(%v, m=%d) = %v, not stable", vec, m, merged)
+				}
+			}
+
+			// next sequence, counting in base len(alphabet)
+			i := 0
+			for i < n && seq[i] == len(alphabet)-1 {
+				seq[i] = 0
+				i++
+			}
+			if i == n {
+				break
+			}
+			seq[i]++
+		}
+	}
+}
+
+func TestSymMergeSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	negZero := math.Copysign(0, -1)
+
+	for _, n := range []int{0, 1, 7, SymMergeRunSize, SymMergeRunSize + 1, 3 * SymMergeRunSize, 1000, 4099} {
+		vec := make([]float64, n)
+		for i := range vec {
+			switch rng.Intn(4) {
+			case 0:
+				vec[i] = negZero
+			case 1:
+				vec[i] = 0
+			default:
+				vec[i] = float64(rng.Intn(20) - 10)
+			}
+		}
+		want := zeroSigns(vec)
+
+		SymMergeSort(vec)
+		if !slices.IsSorted(vec) || !slices.Equal(zeroSigns(vec), want) {
+			t.Errorf("n=%d: not sorted or not stable", n)
+		}
+	}
+}
+
+func BenchmarkSymMergeSort(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"SymMergeSort", SymMergeSort[int]},
+		{"MergeSort", MergeSort[int]},
+	}
+
+	input := RandomInts(1<<16, rand.New(rand.NewSource(BenchmarkSeed)))
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			vec := make([]int, len(input))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(vec, input)
+				bm.sort(vec)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package algorithms
+
+import "time"
+
+// Sorts times from earliest to latest. Times are compared as instants, so
+// the same moment in two different locations counts as equal, and equal
+// times keep their order.
+//
+// Monotonic clock readings are ignored. time.Time.Compare uses them when
+// both times have one and the wall clock otherwise, and if the wall clock
+// was adjusted between readings a mix of both can make the order
+// inconsistent. Only the wall clock is used here
+func SortTimes(vec []time.Time) {
+	MergeSortCmp(vec, compareWall)
+}
+
+// Same as SortTimes but latest first
+func SortTimesDesc(vec []time.Time) {
+	MergeSortCmp(vec, ReverseCmp(compareWall))
+}
+
+func compareWall(a, b time.Time) int {
+	// Round(0) strips the monotonic reading and nothing else
+	return a.Round(0).Compare(b.Round(0))
+}
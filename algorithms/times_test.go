@@ -0,0 +1,62 @@
+package algorithms
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSortTimes(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	newYork := time.FixedZone("EST", -5*60*60)
+	base := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	// readings with a monotonic clock, taken in order
+	now := time.Now()
+	later := now.Add(time.Second)
+
+	vec := []time.Time{
+		base.Add(time.Hour).In(tokyo),    // 0: 13:00 UTC
+		later,                            // 1
+		base.In(newYork),                 // 2: 12:00 UTC
+		now,                              // 3
+		base.Add(-time.Minute).In(tokyo), // 4: 11:59 UTC
+		base,                             // 5: same instant as 2
+		time.Time{},                      // 6
+	}
+	// 2 and 5 are the same instant, 2 is first in the input so it stays first
+	want := []int{6, 4, 2, 5, 0, 3, 1}
+
+	sorted := slices.Clone(vec)
+	SortTimes(sorted)
+	for i, idx := range want {
+		if !sorted[i].Equal(vec[idx]) || sorted[i].Location() != vec[idx].Location() {
+			t.Fatalf("SortTimes: position %d is %v, want %v", i, sorted[i], vec[idx])
+		}
+	}
+
+	desc := slices.Clone(vec)
+	SortTimesDesc(desc)
+	for i := range desc {
+		if !desc[i].Equal(sorted[len(sorted)-1-i]) {
+			t.Fatalf("SortTimesDesc: position %d is %v, want %v", i, desc[i], sorted[len(sorted)-1-i])
+		}
+	}
+}
+
+// Stripping the monotonic reading doesn't change the instant, the sorted
+// times still carry theirs
+func TestSortTimesKeepsMonotonic(t *testing.T) {
+	now := time.Now()
+	vec := []time.Time{now.Add(2 * time.Millisecond), now, now.Add(time.Millisecond)}
+	SortTimes(vec)
+
+	for i, d := range []time.Duration{0, time.Millisecond, 2 * time.Millisecond} {
+		if got := vec[i].Sub(now); got != d {
+			t.Fatalf("position %d is now%+v, want now%+v", i, got, d)
+		}
+		if vec[i] != now.Add(d) {
+			t.Errorf("position %d lost its monotonic reading", i)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package algorithms
+
+import "cmp"
+
+// Sorts vec and then compacts it in place so that every value shows up
+// only once. The returned slice is the deduped prefix of vec (it shares
+// the same backing array), anything after it is left over garbage.
+// An all-duplicates slice comes back with length 1. Sorts with PDQSort,
+// which handles lots of duplicates in O(n log n) where QuickSort goes
+// quadratic.
+func SortUnique[T Ordered](vec []T) []T {
+	if len(vec) <= 1 {
+		return vec
+	}
+
+	PDQSort(vec)
+
+	k := 1
+	for i := 1; i < len(vec); i++ {
+		if vec[i] != vec[k-1] {
+			vec[k] = vec[i]
+			k++
+		}
+	}
+
+	return vec[:k]
+}
+
+// Same as SortUnique but "equal" means cmp(a, b) == 0, so structs can be
+// deduped by key. Uses a stable sort, so the first element of every group
+// of equals (in the original order) is the one that is kept.
+func SortUniqueFunc[T any](vec []T, cmp func(a, b T) int) []T {
+	if len(vec) <= 1 {
+		return vec
+	}
+
+	MergeSortCmp(vec, cmp)
+
+	k := 1
+	for i := 1; i < len(vec); i++ {
+		if cmp(vec[i], vec[k-1]) != 0 {
+			vec[k] = vec[i]
+			k++
+		}
+	}
+
+	return vec[:k]
+}
+
+// One run of equal values in sorted output
+type Group[T any] struct {
+	Value T
+	Count int
+}
+
+// Sorts vec and returns it run-length encoded, [3 1 1 2 3 3] gives
+// {1 2} {2 1} {3 3}
+func SortAndGroup[T Ordered](vec []T) []Group[T] {
+	IntroSort(vec)
+
+	var groups []Group[T]
+	for i, val := range vec {
+		if i == 0 || val != vec[i-1] {
+			groups = append(groups, Group[T]{Value: val})
+		}
+		groups[len(groups)-1].Count++
+	}
+	return groups
+}
+
+// Stable sorts vec by key and returns how many records share each key, in
+// the same order. The records of the ith group are the next Count elements
+// of vec after the groups before it
+func SortAndGroupBy[T any, K Ordered](vec []T, key func(T) K) []Group[K] {
+	MergeSortCmp(vec, func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	})
+
+	var groups []Group[K]
+	for i, val := range vec {
+		k := key(val)
+		if i == 0 || k != groups[len(groups)-1].Value {
+			groups = append(groups, Group[K]{Value: k})
+		}
+		groups[len(groups)-1].Count++
+	}
+	return groups
+}
+
+// Sorted copy of vec plus every distinct value that shows up more than once,
+// in ascending order. vec isn't touched. duplicates is empty (not nil) when
+// every value is unique
+func SortAndFindDuplicates[T Ordered](vec []T) (sorted []T, duplicates []T) {
+	sorted = CopySort(vec)
+	duplicates = make([]T, 0)
+
+	for i := 1; i < len(sorted); i++ {
+		// only the first repeat of a run adds it
+		if sorted[i] == sorted[i-1] && (i == 1 || sorted[i-1] != sorted[i-2]) {
+			duplicates = append(duplicates, sorted[i])
+		}
+	}
+
+	return sorted, duplicates
+}
@@ -0,0 +1,132 @@
+package algorithms
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSortAndGroup(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []int
+		want []Group[int]
+	}{
+		{"example", []int{3, 1, 1, 2, 3, 3}, []Group[int]{{1, 2}, {2, 1}, {3, 3}}},
+		{"empty", nil, nil},
+		{"single", []int{7}, []Group[int]{{7, 1}}},
+		{"all equal", []int{4, 4, 4}, []Group[int]{{4, 3}}},
+		{"all distinct", []int{3, 2, 1}, []Group[int]{{1, 1}, {2, 1}, {3, 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			got := SortAndGroup(vec)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SortAndGroup(%v) = %v, want %v", tt.vec, got, tt.want)
+			}
+			if !slices.IsSorted(vec) {
+				t.Errorf("SortAndGroup didn't sort vec: %v", vec)
+			}
+		})
+	}
+}
+
+func TestSortAndGroupBy(t *testing.T) {
+	words := strings.Fields("pear fig apple kiwi plum date lime grape")
+	groups := SortAndGroupBy(words, func(s string) int { return len(s) })
+
+	if want := []Group[int]{{3, 1}, {4, 5}, {5, 2}}; !slices.Equal(groups, want) {
+		t.Errorf("SortAndGroupBy = %v, want %v", groups, want)
+	}
+	// stable, every group keeps its records in input order
+	if want := strings.Fields("fig pear kiwi plum date lime apple grape"); !slices.Equal(words, want) {
+		t.Errorf("vec = %q, want %q", words, want)
+	}
+
+	if groups := SortAndGroupBy([]string{}, func(s string) int { return len(s) }); groups != nil {
+		t.Errorf("SortAndGroupBy on empty input = %v", groups)
+	}
+}
+
+func TestSortAndFindDuplicates(t *testing.T) {
+	tests := []struct {
+		name       string
+		vec        []int
+		duplicates []int
+	}{
+		{"runs", []int{1, 2, 2, 3, 3, 3, 4}, []int{2, 3}},
+		{"shuffled", []int{3, 4, 2, 3, 1, 3, 2}, []int{2, 3}},
+		{"all unique", []int{5, 1, 4, 2}, []int{}},
+		{"all equal", []int{7, 7, 7, 7}, []int{7}},
+		{"runs back to back", []int{1, 1, 2, 2, 3}, []int{1, 2}},
+		{"at the ends", []int{9, 0, 5, 0, 9}, []int{0, 9}},
+		{"single", []int{1}, []int{}},
+		{"empty", nil, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(tt.vec)
+			sorted, duplicates := SortAndFindDuplicates(vec)
+			if !slices.Equal(sorted, slices.Sorted(slices.Values(tt.vec))) {
+				t.Errorf("sorted = %v", sorted)
+			}
+			if duplicates == nil || !slices.Equal(duplicates, tt.duplicates) {
+				t.Errorf("duplicates = %#v, want %v", duplicates, tt.duplicates)
+			}
+			if !slices.Equal(vec, tt.vec) {
+				t.Errorf("SortAndFindDuplicates changed its input to %v", vec)
+			}
+		})
+	}
+}
+
+func TestSortUnique(t *testing.T) {
+	tests := []struct {
+		name string
+		vec  []int
+		want []int
+	}{
+		{"mixed", []int{3, 1, 2, 3, 1, 5}, []int{1, 2, 3, 5}},
+		{"all duplicates", []int{7, 7, 7, 7, 7}, []int{7}},
+		{"already unique", []int{4, 2, 9}, []int{2, 4, 9}},
+		{"two values", []int{1, 0, 1, 0, 0, 1}, []int{0, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SortUnique(slices.Clone(tt.vec))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SortUnique(%v) = %v, want %v", tt.vec, got, tt.want)
+			}
+		})
+	}
+
+	// a few distinct values in a big slice used to send QuickSort quadratic
+	vec := make([]int, 200_000)
+	for i := range vec {
+		vec[i] = i % 3
+	}
+	if got := SortUnique(vec); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("SortUnique on 3 distinct values = %v", got)
+	}
+}
+
+// The first element of each group of equals, in input order, is kept
+func TestSortUniqueFuncKeepsFirst(t *testing.T) {
+	byKey := func(a, b keyed) int { return a.Key - b.Key }
+	vec := []keyed{{2, 0}, {1, 1}, {2, 2}, {1, 3}, {3, 4}, {2, 5}}
+
+	got := SortUniqueFunc(vec, byKey)
+	want := []keyed{{1, 1}, {2, 0}, {3, 4}}
+	if !slices.Equal(got, want) {
+		t.Errorf("SortUniqueFunc = %v, want %v", got, want)
+	}
+
+	all := []keyed{{5, 0}, {5, 1}, {5, 2}}
+	if got := SortUniqueFunc(all, byKey); !slices.Equal(got, []keyed{{5, 0}}) {
+		t.Errorf("SortUniqueFunc on all duplicates = %v", got)
+	}
+}
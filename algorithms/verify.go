@@ -0,0 +1,77 @@
+package algorithms
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+var ErrSortMismatch = errors.New("algorithms: output differs from slices.Sort")
+
+// Testing helper: reports whether every group of elements with equal keys
+// shows up in after in the same relative order as in before, which is what a
+// stable sort guarantees. It also returns false if after isn't a permutation
+// of before, e.g. when the lengths differ. Elements are matched up with
+// reflect.DeepEqual, so elements with equal keys need something else that
+// tells them apart, the simplest is to pair each one with its position in
+// before. Equal keys on otherwise identical elements always pass
+func CheckStable[T any, K comparable](before []T, after []T, key func(T) K) bool {
+	if len(before) != len(after) {
+		return false
+	}
+
+	// positions in before of every key, in their original order
+	groups := make(map[K][]int)
+	for i, val := range before {
+		k := key(val)
+		groups[k] = append(groups[k], i)
+	}
+
+	for _, val := range after {
+		k := key(val)
+		group := groups[k]
+		if len(group) == 0 || !reflect.DeepEqual(before[group[0]], val) {
+			return false
+		}
+		groups[k] = group[1:]
+	}
+
+	return true
+}
+
+// Testing helper, e.g. for fuzz tests: runs algo on a copy of vec and
+// slices.Sort on another copy and returns an error wrapping ErrSortMismatch
+// if they don't agree. The error says whether algo lost or made up elements
+// (its output isn't a permutation of vec) and otherwise gives the first index
+// where it differs from the reference. vec isn't touched. Floats with NaN
+// can't be checked, NaN isn't equal to itself
+func VerifySort[T Ordered](algo Sorter[T], vec []T) error {
+	got := slices.Clone(vec)
+	algo(got)
+
+	want := slices.Clone(vec)
+	slices.Sort(want)
+
+	if slices.Equal(got, want) {
+		return nil
+	}
+
+	// sorting the output tells if it still has the same elements, in that
+	// case they're just in the wrong order
+	gotSorted := slices.Clone(got)
+	slices.Sort(gotSorted)
+	for i := range gotSorted {
+		if gotSorted[i] != want[i] {
+			return fmt.Errorf("%w: output isn't a permutation of the input, sorted it has %v at index %d, want %v",
+				ErrSortMismatch, gotSorted[i], i, want[i])
+		}
+	}
+
+	for i := range got {
+		if got[i] != want[i] {
+			return fmt.Errorf("%w: index %d is %v, want %v", ErrSortMismatch, i, got[i], want[i])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package algorithms
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// A sort key paired with the element's position in the input, which is what
+// tells equal keys apart for CheckStable
+type keyed struct {
+	Key int
+	Pos int
+}
+
+func keyedInts(n, keys int, rng *rand.Rand) []keyed {
+	vec := make([]keyed, n)
+	for i := range vec {
+		vec[i] = keyed{rng.Intn(keys), i}
+	}
+	return vec
+}
+
+func TestCheckStable(t *testing.T) {
+	byKey := func(a, b keyed) int { return cmp.Compare(a.Key, b.Key) }
+	key := func(v keyed) int { return v.Key }
+
+	tests := []struct {
+		name string
+		sort func([]keyed)
+		want bool
+	}{
+		{"MergeSortCmp", func(v []keyed) { MergeSortCmp(v, byKey) }, true},
+		{"InsertionSortCmp", func(v []keyed) { InsertionSortCmp(v, byKey) }, true},
+		{"slices.SortStableFunc", func(v []keyed) { slices.SortStableFunc(v, byKey) }, true},
+		{"QuickSortCmp", func(v []keyed) { QuickSortCmp(v, byKey) }, false},
+		{"slices.SortFunc", func(v []keyed) { slices.SortFunc(v, byKey) }, false},
+	}
+
+	before := keyedInts(1000, 10, rand.New(rand.NewSource(1)))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := slices.Clone(before)
+			tt.sort(after)
+			if got := CheckStable(before, after, key); got != tt.want {
+				t.Errorf("CheckStable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckStableNotAPermutation(t *testing.T) {
+	key := func(v keyed) int { return v.Key }
+	before := []keyed{{1, 0}, {0, 1}, {1, 2}}
+
+	tests := []struct {
+		name  string
+		after []keyed
+		want  bool
+	}{
+		{"stable", []keyed{{0, 1}, {1, 0}, {1, 2}}, true},
+		{"swapped", []keyed{{0, 1}, {1, 2}, {1, 0}}, false},
+		{"shorter", []keyed{{0, 1}, {1, 0}}, false},
+		{"longer", []keyed{{0, 1}, {1, 0}, {1, 2}, {1, 3}}, false},
+		{"empty", nil, false},
+		{"element made up", []keyed{{0, 1}, {1, 0}, {1, 5}}, false},
+		{"key made up", []keyed{{0, 1}, {1, 0}, {2, 2}}, false},
+		{"duplicated", []keyed{{0, 1}, {1, 0}, {1, 0}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckStable(before, tt.after, key); got != tt.want {
+				t.Errorf("CheckStable(%v, %v) = %v, want %v", before, tt.after, got, tt.want)
+			}
+		})
+	}
+
+	if !CheckStable([]keyed{}, nil, key) {
+		t.Errorf("CheckStable on two empty slices = false")
+	}
+}
+
+func TestVerifySort(t *testing.T) {
+	// distinct values, so every buggy sorter below really is wrong
+	in := SortedInts(1000)
+	Shuffle(in, rand.New(rand.NewSource(1)))
+
+	tests := []struct {
+		name    string
+		algo    Sorter[int]
+		wantErr string
+	}{
+		{"IntroSort", IntroSort[int], ""},
+		{"MergeSort", MergeSort[int], ""},
+		{"does nothing", func([]int) {}, "index 0"},
+		{"last two swapped", func(v []int) {
+			slices.Sort(v)
+			v[len(v)-2], v[len(v)-1] = v[len(v)-1], v[len(v)-2]
+		}, "index 998"},
+		{"descending", func(v []int) { slices.Sort(v); slices.Reverse(v) }, "index 0"},
+		{"loses an element", func(v []int) { slices.Sort(v); v[0] = v[1] }, "isn't a permutation"},
+		{"makes one up", func(v []int) { slices.Sort(v); v[len(v)-1]++ }, "isn't a permutation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := slices.Clone(in)
+			err := VerifySort(tt.algo, vec)
+			if !slices.Equal(vec, in) {
+				t.Errorf("VerifySort changed its input")
+			}
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("VerifySort = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrSortMismatch) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("VerifySort = %v, want ErrSortMismatch mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := VerifySort(IntroSort[int], nil); err != nil {
+		t.Errorf("VerifySort on nil input = %v", err)
+	}
+}
+
+// Every registered sorter has to pass on fuzzed input
+func FuzzVerifySort(f *testing.F) {
+	f.Add([]byte{3, 1, 2})
+	f.Add([]byte{})
+	f.Add([]byte{5, 5, 5, 0, 255, 128})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		vec := make([]int, len(data))
+		for i, b := range data {
+			vec[i] = int(int8(b))
+		}
+		for name, algo := range Sorters[int]() {
+			if err := VerifySort(algo, vec); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+		}
+	})
+}